@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/github"
+	"github.com/saltyorg/docs-automation/internal/links"
+	"github.com/saltyorg/docs-automation/internal/runtime"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkLinksCache   string
+	checkLinksOffline bool
+)
+
+var checkLinksCmd = &cobra.Command{
+	Use:   "check-links [role...]",
+	Short: "Check external links in app documentation",
+	Long: `Check the external links of app documentation.
+
+Links are collected from saltbox_automation frontmatter (app_links and
+project_description.link) and from the managed overview and variables
+sections. Each link is validated syntactically and then requested over
+HTTP (HEAD, falling back to GET) with the concurrency, per-host rate limit
+and retries configured under link_check.
+
+Results are stored in the link_check.cache_file (or --cache) and reused
+while younger than link_check.max_age (default 24h). Network errors, 429
+and 5xx responses are not cached, so they are retried on the next run.
+With --offline no requests are made: only cached results are reported, at
+any age, which lets CI run against a recorded cache. Links never checked
+are listed as unchecked, not broken.
+
+Exits with an error when broken links are found.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		result := &github.CheckResult{}
+		if err := runLinkChecks(cfg, args, result); err != nil {
+			return err
+		}
+		for _, annotation := range github.CheckAnnotations(result, cfg.Repositories.Docs, nil) {
+			annotator.Add(annotation)
+		}
+
+		if len(result.BrokenLinks) > 0 {
+			return fmt.Errorf("found %d broken link(s)", len(result.BrokenLinks))
+		}
+		fmt.Println("✅ No broken links found")
+		return nil
+	},
+}
+
+func init() {
+	checkLinksCmd.Flags().StringVar(&checkLinksCache, "cache", "", "link check cache file (default: link_check.cache_file)")
+	checkLinksCmd.Flags().BoolVar(&checkLinksOffline, "offline", false, "only report cached results, make no requests")
+	rootCmd.AddCommand(checkLinksCmd)
+}
+
+// runLinkChecks checks the external links of app docs and records broken ones
+// in result. With roles given, only the docs of those roles are checked.
+func runLinkChecks(cfg *config.Config, roles []string, result *github.CheckResult) error {
+	lc := cfg.LinkCheck
+
+	cachePath := lc.CacheFile
+	if checkLinksCache != "" {
+		cachePath = checkLinksCache
+	}
+	cache, err := links.LoadCache(cachePath)
+	if err != nil {
+		return fmt.Errorf("loading link cache: %w", err)
+	}
+
+	found, err := collectDocLinks(cfg, roles)
+	if err != nil {
+		return err
+	}
+
+	var urls []string
+	var checked []links.Link
+	for _, link := range found {
+		if hasAnyPrefix(link.URL, lc.Ignore) {
+			continue
+		}
+		checked = append(checked, link)
+		urls = append(urls, link.URL)
+	}
+
+	checker := &links.Checker{
+		Client:      &http.Client{Timeout: lc.Duration(lc.Timeout, 15*time.Second)},
+		Cache:       cache,
+		Concurrency: lc.Concurrency,
+		RateLimit:   lc.Duration(lc.RateLimit, 0),
+		Retries:     lc.Retries,
+		MaxAge:      lc.Duration(lc.MaxAge, links.DefaultMaxAge),
+		Offline:     checkLinksOffline,
+		UserAgent:   "sb-docs/" + runtime.Version,
+	}
+	results := checker.Check(context.Background(), urls)
+
+	if err := cache.Save(); err != nil {
+		return fmt.Errorf("saving link cache: %w", err)
+	}
+
+	broken, unchecked := 0, 0
+	for _, link := range checked {
+		res := results[link.URL]
+		if res.OK() {
+			continue
+		}
+		rel, err := filepath.Rel(cfg.Repositories.Docs, link.File)
+		if err != nil {
+			rel = link.File
+		}
+		location := rel
+		if link.Line > 0 {
+			location = fmt.Sprintf("%s:%d", rel, link.Line)
+		}
+
+		if !links.Broken(res) {
+			unchecked++
+			if IsVerbose() {
+				fmt.Printf("❔ %s: %s (%s)\n", location, link.URL, res.Error)
+			}
+			continue
+		}
+		broken++
+		fmt.Printf("❌ %s: %s (%s)\n", location, link.URL, res.Error)
+		result.BrokenLinks = append(result.BrokenLinks, fmt.Sprintf("%s: %s (%s)", location, link.URL, res.Error))
+	}
+
+	fmt.Printf("Checked %d links (%d unique): %d broken", len(checked), len(results), broken)
+	if unchecked > 0 {
+		fmt.Printf(", %d not in cache", unchecked)
+	}
+	fmt.Println()
+	return nil
+}
+
+// collectDocLinks extracts the links of saltbox and sandbox app docs.
+func collectDocLinks(cfg *config.Config, roles []string) ([]links.Link, error) {
+	selected := make(map[string]bool)
+	for _, role := range roles {
+		selected[role] = true
+	}
+
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+	sections := []string{cfg.Markers.Overview, cfg.Markers.Variables}
+
+	var paths []string
+	for _, dir := range []string{cfg.SaltboxDocsPath(), cfg.SandboxDocsPath()} {
+		files, err := docs.ListDocFiles(dir)
+		if err != nil {
+			return nil, fmt.Errorf("listing docs in %s: %w", dir, err)
+		}
+		for _, path := range files {
+			if len(selected) == 0 || selected[docs.ExtractRoleName(path)] {
+				paths = append(paths, path)
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	var found []links.Link
+	for _, path := range paths {
+		doc, err := manager.LoadDocument(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		found = append(found, links.Extract(doc, sections)...)
+	}
+	return found, nil
+}
+
+// hasAnyPrefix reports whether s starts with any of the prefixes.
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/overview"
+	"github.com/saltyorg/docs-automation/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	enrichLabelsPath string
+	enrichDryRun     bool
+)
+
+var enrichCmd = &cobra.Command{
+	Use:   "enrich",
+	Short: "Fill in documentation metadata from external sources",
+	Long:  "Fill in documentation frontmatter from external sources.",
+}
+
+var enrichOverviewCmd = &cobra.Command{
+	Use:   "overview [role...]",
+	Short: "Fill in overview frontmatter from image labels",
+	Long: `Fill in project_description and app_links frontmatter from OCI image labels.
+
+The image of each role is read from its *_docker_image default and looked up
+in a local label cache (--labels), a JSON file or a directory of JSON files
+holding "docker inspect" output, image configs or label sets.
+
+Labels are mapped as follows:
+  org.opencontainers.image.title          project_description.name
+  org.opencontainers.image.description    project_description.summary
+  org.opencontainers.image.url            project_description.link, Home link
+  org.opencontainers.image.documentation  Manual link
+  org.opencontainers.image.source         GitHub (or Source) link
+
+Fields that are already set are never overwritten, and a link is only added
+when no existing link has the same type or URL. Only the saltbox_automation
+frontmatter is rewritten. Without role arguments every documented role is
+enriched.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		cache, err := overview.LoadLabelCache(enrichLabelsPath)
+		if err != nil {
+			return fmt.Errorf("loading labels: %w", err)
+		}
+
+		return enrichOverview(cfg, cache, args)
+	},
+}
+
+func init() {
+	enrichOverviewCmd.Flags().StringVar(&enrichLabelsPath, "labels", "", "JSON file or directory of image labels (required)")
+	enrichOverviewCmd.Flags().BoolVar(&enrichDryRun, "dry-run", false, "print proposed changes without writing")
+	_ = enrichOverviewCmd.MarkFlagRequired("labels")
+	enrichCmd.AddCommand(enrichOverviewCmd)
+	rootCmd.AddCommand(enrichCmd)
+}
+
+// enrichOverview applies image label metadata to the frontmatter of roles.
+func enrichOverview(cfg *config.Config, cache overview.LabelCache, only []string) error {
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+
+	inventory, err := parser.LoadInventoryValues(cfg.InventoryPath())
+	if err != nil {
+		return fmt.Errorf("loading inventory: %w", err)
+	}
+
+	selected := make(map[string]bool)
+	for _, role := range only {
+		selected[role] = true
+	}
+
+	documented := make(map[string]bool)
+	updated := 0
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		rolesPath := rolesPathFor(cfg, repoType)
+		roles, err := listRoles(rolesPath)
+		if err != nil {
+			return fmt.Errorf("listing %s roles: %w", repoType, err)
+		}
+		if repoType == "saltbox" {
+			roles = filterBlacklist(roles, cfg.Blacklist.DocsCoverage.Saltbox)
+		} else {
+			roles = filterBlacklist(roles, cfg.Blacklist.DocsCoverage.Sandbox)
+		}
+
+		for _, role := range roles {
+			if len(selected) > 0 && !selected[role] {
+				continue
+			}
+			docPath := getDocPath(cfg, role, repoType)
+			if _, err := os.Stat(docPath); err != nil {
+				continue
+			}
+			documented[role] = true
+
+			image, err := roleDockerImage(filepath.Join(rolesPath, role), role, inventory)
+			if err != nil {
+				return fmt.Errorf("%s: %w", role, err)
+			}
+			if image == "" {
+				continue
+			}
+			labels, ok := cache.Lookup(image)
+			if !ok {
+				fmt.Printf("⏭️  %s: no labels for %s\n", role, image)
+				continue
+			}
+
+			doc, err := manager.LoadDocument(docPath)
+			if err != nil {
+				return fmt.Errorf("%s: %w", role, err)
+			}
+			var fmConfig *docs.SaltboxAutomationConfig
+			if doc.Frontmatter != nil {
+				fmConfig = doc.Frontmatter.SaltboxAutomation
+			}
+
+			proposal := overview.ProposeFromLabels(labels).Merge(fmConfig)
+			if proposal.Empty() {
+				continue
+			}
+			printProposal(role, image, proposal)
+
+			if enrichDryRun {
+				updated++
+				continue
+			}
+			changed, err := proposal.Apply(doc)
+			if err != nil {
+				return fmt.Errorf("%s: %w", role, err)
+			}
+			if changed {
+				if err := manager.SaveDocument(doc); err != nil {
+					return fmt.Errorf("%s: saving document: %w", role, err)
+				}
+				updated++
+			}
+		}
+	}
+
+	for _, role := range only {
+		if !documented[role] {
+			return fmt.Errorf("role %q has no documentation", role)
+		}
+	}
+
+	if enrichDryRun {
+		fmt.Printf("\n%d of %d documents would be updated (dry run)\n", updated, len(documented))
+	} else {
+		fmt.Printf("\n%d of %d documents updated\n", updated, len(documented))
+	}
+	return nil
+}
+
+// roleDockerImage returns the effective image of a role from its *_docker_image
+// defaults, preferring <role>_role_docker_image. It returns "" when the role
+// has no image or its value cannot be resolved.
+func roleDockerImage(rolePath, roleName string, inventory map[string]string) (string, error) {
+	defaultsPath := filepath.Join(rolePath, "defaults", "main.yml")
+	if _, err := os.Stat(defaultsPath); os.IsNotExist(err) {
+		return "", nil
+	}
+
+	info, err := parser.New(roleName, "").ParseFile(defaultsPath)
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []*parser.Variable
+	for i := range info.AllVariables {
+		v := &info.AllVariables[i]
+		if strings.HasSuffix(v.Name, "_docker_image") {
+			candidates = append(candidates, v)
+		}
+	}
+	preferred := roleName + "_role_docker_image"
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Name == preferred && candidates[j].Name != preferred
+	})
+
+	resolver := parser.NewResolver(roleName, info.AllVariables, inventory)
+	for _, v := range candidates {
+		if image, ok := resolver.Value(v.RawValue); ok && image != "" {
+			return image, nil
+		}
+	}
+	return "", nil
+}
+
+// printProposal prints the frontmatter fields that would be added to a role.
+func printProposal(role, image string, p overview.Proposal) {
+	fmt.Printf("📝 %s (%s)\n", role, image)
+	fields := []struct{ key, value string }{
+		{"name", p.Description.Name},
+		{"summary", p.Description.Summary},
+		{"link", p.Description.Link},
+	}
+	for _, field := range fields {
+		if field.value != "" {
+			fmt.Printf("   + project_description.%s: %s\n", field.key, field.value)
+		}
+	}
+	for _, link := range p.Links {
+		fmt.Printf("   + app_links: %s (%s) %s\n", link.Name, link.Type, link.URL)
+	}
+}
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	explainRole string
+	explainKind string
+)
+
+var explainTypeCmd = &cobra.Command{
+	Use:   "explain-type <variable>",
+	Short: "Explain how a variable type is inferred",
+	Long: `Explain how the documented type of a variable is inferred.
+
+Prints the decision trace of type inference: every rule tier evaluated,
+which one matched, the suffix or pattern involved and the value that
+triggered it.
+
+The argument can be:
+  - a role variable (e.g. plex_role_web_port)
+  - a role_var suffix looked up in the inventory (e.g. _web_host_override)
+  - a Docker+ suffix (e.g. _docker_dev_dri or dev_dri)
+
+The kind is detected automatically; use --kind to force one.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return explainType(cfg, args[0])
+	},
+}
+
+func init() {
+	explainTypeCmd.Flags().StringVar(&explainRole, "role", "", "role that defines the variable (default: detected from the variable prefix)")
+	explainTypeCmd.Flags().StringVar(&explainKind, "kind", "auto", "kind of name to explain: auto, variable, role_var or docker")
+	rootCmd.AddCommand(explainTypeCmd)
+}
+
+// explainType prints the type inference trace for a variable, role_var suffix or Docker+ suffix.
+func explainType(cfg *config.Config, name string) error {
+	switch explainKind {
+	case "variable":
+		return explainRoleVariable(cfg, name)
+	case "role_var":
+		return explainRoleVarSuffix(cfg, name)
+	case "docker":
+		return explainDockerSuffix(name)
+	case "auto":
+	default:
+		return fmt.Errorf("unknown kind %q (expected auto, variable, role_var or docker)", explainKind)
+	}
+
+	// Role variables are the most common case
+	if explainRole != "" || !strings.HasPrefix(name, "_") {
+		if err := explainRoleVariable(cfg, name); err == nil || explainRole != "" {
+			return err
+		}
+	}
+
+	// role_var suffixes looked up in the inventory
+	occurrences, err := parser.FindRoleVarOccurrences(cfg.InventoryPath(), name)
+	if err != nil {
+		return fmt.Errorf("scanning inventory: %w", err)
+	}
+	if len(occurrences) > 0 {
+		return explainRoleVarSuffix(cfg, name)
+	}
+
+	// Docker+ suffixes from resources/tasks/docker
+	scanner := parser.NewDockerVarScanner(filepath.Join(cfg.Repositories.Saltbox, "resources"))
+	dockerVars, err := scanner.FindDockerVarLookups()
+	if err != nil {
+		return fmt.Errorf("scanning docker vars: %w", err)
+	}
+	normalized := parser.NormalizeDockerSuffix(name)
+	for _, suffix := range dockerVars {
+		if suffix == normalized {
+			return explainDockerSuffix(name)
+		}
+	}
+
+	return fmt.Errorf("%q is not a role variable, inventory role_var suffix or Docker+ suffix (use --kind to force)", name)
+}
+
+// explainRoleVariable prints the inference trace for a variable defined in role defaults.
+func explainRoleVariable(cfg *config.Config, name string) error {
+	roleName, repoType, variable, err := findRoleVariable(cfg, name)
+	if err != nil {
+		return err
+	}
+
+	// Example overrides replace the value before inference (see BuildRoleData)
+	value := variable.RawValue
+	fmConfig := loadFrontmatterConfig(cfg, roleName, repoType)
+	override, hasOverride := fmConfig.GetExampleOverride(name)
+	if hasOverride {
+		value = override
+	}
+
+	typeInfer := parser.NewTypeInferrer(&cfg.TypeInference)
+	usage, err := parser.ScanRoleUsage(filepath.Join(rolesPathFor(cfg, repoType), roleName), roleName)
+	if err != nil {
+		return fmt.Errorf("scanning role usage: %w", err)
+	}
+	typeInfer.SetUsage(usage)
+	decision := typeInfer.Explain(name, value)
+
+	fmt.Printf("Variable:   %s\n", name)
+	fmt.Printf("Role:       %s (%s)\n", roleName, repoType)
+	fmt.Printf("Value:      %s\n", formatExplainValue(variable.RawValue))
+	if hasOverride {
+		fmt.Printf("Override:   %s (from inventory.example_overrides)\n", formatExplainValue(override))
+	}
+	if u, ok := usage[name]; ok {
+		fmt.Println("Usage:")
+		for _, e := range u.Evidence {
+			fmt.Printf("  %s -> %s (%s)\n", e.Location(), e.Type, e.Detail)
+		}
+	}
+	printTypeDecision(decision)
+	return nil
+}
+
+// findRoleVariable locates a variable in role defaults.
+// Without --role, candidate roles are those whose name prefixes the variable, longest first.
+func findRoleVariable(cfg *config.Config, name string) (string, string, *parser.Variable, error) {
+	type candidate struct {
+		role     string
+		repoType string
+	}
+
+	var candidates []candidate
+	if explainRole != "" {
+		candidates = append(candidates, candidate{explainRole, "saltbox"}, candidate{explainRole, "sandbox"})
+	} else {
+		for _, repoType := range []string{"saltbox", "sandbox"} {
+			roles, err := listRoles(rolesPathFor(cfg, repoType))
+			if err != nil {
+				return "", "", nil, fmt.Errorf("listing %s roles: %w", repoType, err)
+			}
+			for _, role := range roles {
+				if strings.HasPrefix(name, role+"_") {
+					candidates = append(candidates, candidate{role, repoType})
+				}
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return len(candidates[i].role) > len(candidates[j].role)
+		})
+	}
+
+	for _, c := range candidates {
+		defaultsPath := filepath.Join(rolesPathFor(cfg, c.repoType), c.role, "defaults", "main.yml")
+		if _, err := os.Stat(defaultsPath); err != nil {
+			continue
+		}
+
+		roleInfo, err := parser.New(c.role, c.repoType).ParseFile(defaultsPath)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("parsing role %q: %w", c.role, err)
+		}
+
+		for i := range roleInfo.AllVariables {
+			if roleInfo.AllVariables[i].Name == name {
+				return c.role, c.repoType, &roleInfo.AllVariables[i], nil
+			}
+		}
+	}
+
+	if explainRole != "" {
+		return "", "", nil, fmt.Errorf("variable %q not found in role %q defaults", name, explainRole)
+	}
+	return "", "", nil, fmt.Errorf("variable %q not found in any role defaults", name)
+}
+
+// explainRoleVarSuffix prints the inference trace for a role_var suffix from the inventory.
+func explainRoleVarSuffix(cfg *config.Config, suffix string) error {
+	occurrences, err := parser.FindRoleVarOccurrences(cfg.InventoryPath(), suffix)
+	if err != nil {
+		return fmt.Errorf("scanning inventory: %w", err)
+	}
+	if len(occurrences) == 0 {
+		return fmt.Errorf("no role_var lookups of %q found in %s", suffix, cfg.InventoryPath())
+	}
+
+	for _, ignored := range cfg.GlobalOverrides.IgnoreSuffixes {
+		if ignored == suffix {
+			fmt.Printf("Note: %s is listed in global_overrides.ignore_suffixes and is not documented\n\n", suffix)
+			break
+		}
+	}
+
+	fmt.Printf("Suffix:     %s\n", suffix)
+	fmt.Printf("Lookups:    %d in %s\n", len(occurrences), cfg.InventoryPath())
+	for _, occ := range occurrences {
+		fmt.Printf("  line %d -> %s (%s)\n", occ.LineNumber, occ.Decision.Type, occ.Decision.Rule)
+	}
+
+	effective := parser.EffectiveRoleVarOccurrence(occurrences)
+	decision := effective.Decision
+	fmt.Printf("Using line: %d\n", effective.LineNumber)
+
+	// Configured types in global_overrides take precedence (see BuildRoleData)
+	if varDef, ok := cfg.GlobalOverrides.Variables[suffix]; ok && varDef.Type != "" {
+		decision.Steps = append(decision.Steps, parser.TypeStep{
+			Rule:    parser.RuleGlobalOverride,
+			Matched: true,
+			Pattern: suffix,
+			Detail:  fmt.Sprintf("global_overrides.variables type replaces inferred %q", decision.Type),
+		})
+		decision.Type = varDef.Type
+		decision.Rule = parser.RuleGlobalOverride
+		decision.Pattern = suffix
+	}
+
+	printTypeDecision(decision)
+	return nil
+}
+
+// explainDockerSuffix prints the inference trace for a Docker+ suffix.
+func explainDockerSuffix(suffix string) error {
+	decision := parser.ExplainDockerVarType(suffix)
+
+	fmt.Printf("Docker+:    %s\n", parser.NormalizeDockerSuffix(suffix))
+	printTypeDecision(decision)
+	return nil
+}
+
+// printTypeDecision prints the final type and the decision trace.
+func printTypeDecision(d *parser.TypeDecision) {
+	fmt.Printf("Type:       %s\n", d.Type)
+	if d.Pattern != "" {
+		fmt.Printf("Decided by: %s (%s)\n", d.Rule, d.Pattern)
+	} else {
+		fmt.Printf("Decided by: %s\n", d.Rule)
+	}
+
+	fmt.Println()
+	fmt.Println("Trace:")
+	for i, step := range d.Steps {
+		mark := "✗"
+		if step.Matched {
+			mark = "✓"
+		}
+		fmt.Printf("  %d. %s %-15s %s\n", i+1, mark, step.Rule, step.Detail)
+	}
+}
+
+// formatExplainValue formats a raw value for single-line display.
+func formatExplainValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	if strings.Contains(value, "\n") {
+		return fmt.Sprintf("%q", value)
+	}
+	return value
+}
+
+// rolesPathFor returns the roles directory for a repo type.
+func rolesPathFor(cfg *config.Config, repoType string) string {
+	if repoType == "saltbox" {
+		return cfg.SaltboxRolesPath()
+	}
+	return cfg.SandboxRolesPath()
+}
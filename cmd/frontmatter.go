@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/spf13/cobra"
+)
+
+var frontmatterDryRun bool
+
+var frontmatterCmd = &cobra.Command{
+	Use:   "frontmatter",
+	Short: "Edit saltbox_automation frontmatter",
+	Long:  "Edit the saltbox_automation frontmatter of documentation files.",
+}
+
+var frontmatterSetCmd = &cobra.Command{
+	Use:   "set <role> <path> <value>",
+	Short: "Set a saltbox_automation frontmatter value",
+	Long: `Set a saltbox_automation frontmatter value in a role's documentation.
+
+The path is dotted and relative to saltbox_automation; numeric segments index
+lists, and an index equal to the list length appends. The value is parsed as
+YAML, so booleans, lists and mappings can be given inline.
+
+Only the saltbox_automation block is rewritten. Other frontmatter keys
+(title, tags, hide, ...), their comments and the document body are kept
+byte-identical.
+
+Examples:
+  sb-docs frontmatter set plex sections.inventory false
+  sb-docs frontmatter set plex project_description.categories "[Media, Streaming]"
+  sb-docs frontmatter set plex app_links.0 "{name: Manual, url: https://support.plex.tv, type: manual}"`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return setFrontmatter(cfg, args[0], args[1], args[2])
+	},
+}
+
+func init() {
+	frontmatterSetCmd.Flags().BoolVar(&frontmatterDryRun, "dry-run", false, "print the updated frontmatter without writing")
+	frontmatterCmd.AddCommand(frontmatterSetCmd)
+	rootCmd.AddCommand(frontmatterCmd)
+}
+
+// setFrontmatter sets a saltbox_automation value in the documentation of a role.
+func setFrontmatter(cfg *config.Config, roleName, path, value string) error {
+	docPath := findRoleDocPath(cfg, roleName)
+	if docPath == "" {
+		return fmt.Errorf("no documentation found for role %q", roleName)
+	}
+
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+
+	doc, err := manager.LoadDocument(docPath)
+	if err != nil {
+		return err
+	}
+
+	changed, err := doc.SetSaltboxAutomation(path, value)
+	if err != nil {
+		return fmt.Errorf("setting %s: %w", path, err)
+	}
+	if !changed {
+		fmt.Printf("✅ %s already set in %s\n", path, docPath)
+		return nil
+	}
+	if err := validateSaltboxAutomation(doc.Frontmatter.SaltboxAutomation); err != nil {
+		return fmt.Errorf("invalid frontmatter after setting %s: %w", path, err)
+	}
+
+	if frontmatterDryRun {
+		fmt.Printf("---\n%s\n---\n", doc.Frontmatter.Raw)
+		return nil
+	}
+
+	if err := manager.SaveDocument(doc); err != nil {
+		return fmt.Errorf("saving document: %w", err)
+	}
+	fmt.Printf("✅ Set %s in %s\n", path, docPath)
+	return nil
+}
+
+// findRoleDocPath returns the existing documentation file of a role,
+// looking in saltbox first, then sandbox. It returns "" if there is none.
+func findRoleDocPath(cfg *config.Config, roleName string) string {
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		docPath := getDocPath(cfg, roleName, repoType)
+		if docPath == "" {
+			continue
+		}
+		if _, err := os.Stat(docPath); err == nil {
+			return docPath
+		}
+	}
+	return ""
+}
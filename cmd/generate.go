@@ -8,6 +8,7 @@ import (
 	"github.com/saltyorg/docs-automation/internal/cli"
 	"github.com/saltyorg/docs-automation/internal/config"
 	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/github"
 	"github.com/saltyorg/docs-automation/internal/parser"
 	"github.com/saltyorg/docs-automation/internal/template"
 	"github.com/spf13/cobra"
@@ -69,38 +70,27 @@ func generateRole(cfg *config.Config, roleName string) error {
 	}
 
 	// Parse the role
-	p := parser.New(roleName, repoType)
-	roleInfo, err := p.ParseFile(defaultsPath)
+	roleInfo, err := parseRole(roleName, repoType, defaultsPath)
 	if err != nil {
 		return fmt.Errorf("parsing role %q: %w", roleName, err)
 	}
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Note: Variable filtering is now done in BuildRoleData to ensure
 	// sections are also filtered consistently
 
 	// Try to load frontmatter from existing doc
-	var fmConfig *docs.SaltboxAutomationConfig
-	docPath := getDocPath(cfg, roleName, repoType)
-	if docPath != "" {
-		if content, err := os.ReadFile(docPath); err == nil {
-			if fm, _, err := docs.ParseFrontmatter(string(content)); err == nil && fm != nil {
-				fmConfig = fm.SaltboxAutomation
-			}
-		}
-	}
+	fmConfig := loadFrontmatterConfig(cfg, roleName, repoType)
 
 	// Build template data
-	data := template.BuildRoleData(roleInfo, cfg, fmConfig)
-
-	// Create template engine and render
-	engine := template.New()
-	if err := engine.LoadFile("inventory", cfg.InventoryTemplatePath()); err != nil {
-		return fmt.Errorf("loading template: %w", err)
-	}
+	data := template.BuildRoleData(roleInfo, cfg, fmConfig, sources.playbookTags[repoType], sources.inventory)
 
-	output, err := engine.Render("inventory", data)
+	output, err := renderInventory(cfg, data, fmConfig)
 	if err != nil {
-		return fmt.Errorf("rendering template: %w", err)
+		return err
 	}
 
 	fmt.Print(output)
@@ -130,12 +120,17 @@ func generateAllRoles(cfg *config.Config) error {
 			len(saltboxRoles), len(sandboxRoles))
 	}
 
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return err
+	}
+
 	// Generate each role
 	for _, role := range saltboxRoles {
 		if IsVerbose() {
 			fmt.Fprintf(os.Stderr, "Generating: %s (saltbox)\n", role)
 		}
-		if err := generateRoleWithType(cfg, role, "saltbox"); err != nil {
+		if err := generateRoleWithType(cfg, sources, role, "saltbox"); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to generate %s: %v\n", role, err)
 		}
 	}
@@ -144,7 +139,7 @@ func generateAllRoles(cfg *config.Config) error {
 		if IsVerbose() {
 			fmt.Fprintf(os.Stderr, "Generating: %s (sandbox)\n", role)
 		}
-		if err := generateRoleWithType(cfg, role, "sandbox"); err != nil {
+		if err := generateRoleWithType(cfg, sources, role, "sandbox"); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to generate %s: %v\n", role, err)
 		}
 	}
@@ -160,7 +155,7 @@ func generateAllRoles(cfg *config.Config) error {
 }
 
 // generateRoleWithType generates documentation for a role with known repo type.
-func generateRoleWithType(cfg *config.Config, roleName, repoType string) error {
+func generateRoleWithType(cfg *config.Config, sources *roleSources, roleName, repoType string) error {
 	var rolesPath string
 	if repoType == "saltbox" {
 		rolesPath = cfg.SaltboxRolesPath()
@@ -176,8 +171,7 @@ func generateRoleWithType(cfg *config.Config, roleName, repoType string) error {
 	}
 
 	// Parse the role
-	p := parser.New(roleName, repoType)
-	roleInfo, err := p.ParseFile(defaultsPath)
+	roleInfo, err := parseRole(roleName, repoType, defaultsPath)
 	if err != nil {
 		return fmt.Errorf("parsing: %w", err)
 	}
@@ -195,28 +189,14 @@ func generateRoleWithType(cfg *config.Config, roleName, repoType string) error {
 	}
 
 	// Try to load frontmatter from existing doc
-	var fmConfig *docs.SaltboxAutomationConfig
-	docPath := getDocPath(cfg, roleName, repoType)
-	if docPath != "" {
-		if content, err := os.ReadFile(docPath); err == nil {
-			if fm, _, err := docs.ParseFrontmatter(string(content)); err == nil && fm != nil {
-				fmConfig = fm.SaltboxAutomation
-			}
-		}
-	}
+	fmConfig := loadFrontmatterConfig(cfg, roleName, repoType)
 
 	// Build template data
-	data := template.BuildRoleData(roleInfo, cfg, fmConfig)
-
-	// Create template engine and render
-	engine := template.New()
-	if err := engine.LoadFile("inventory", cfg.InventoryTemplatePath()); err != nil {
-		return fmt.Errorf("loading template: %w", err)
-	}
+	data := template.BuildRoleData(roleInfo, cfg, fmConfig, sources.playbookTags[repoType], sources.inventory)
 
-	output, err := engine.Render("inventory", data)
+	output, err := renderInventory(cfg, data, fmConfig)
 	if err != nil {
-		return fmt.Errorf("rendering: %w", err)
+		return err
 	}
 
 	// Print with role header for clarity
@@ -277,6 +257,124 @@ func getDocPath(cfg *config.Config, roleName, repoType string) string {
 	return docPath
 }
 
+// loadFrontmatterConfig returns the saltbox_automation frontmatter of a role's doc.
+// Returns nil if the doc does not exist or has no valid frontmatter.
+func loadFrontmatterConfig(cfg *config.Config, roleName, repoType string) *docs.SaltboxAutomationConfig {
+	docPath := getDocPath(cfg, roleName, repoType)
+	if docPath == "" {
+		return nil
+	}
+	content, err := os.ReadFile(docPath)
+	if err != nil {
+		return nil
+	}
+	fm, _, err := docs.ParseFrontmatter(string(content))
+	if err != nil || fm == nil {
+		return nil
+	}
+	return fm.SaltboxAutomation
+}
+
+// parseRole parses a role's defaults/main.yml, the roles it depends on and
+// how it uses its variables. Dependencies and usage are best-effort, so
+// errors reading them are warnings.
+func parseRole(roleName, repoType, defaultsPath string) (*parser.RoleInfo, error) {
+	roleInfo, err := parser.New(roleName, repoType).ParseFile(defaultsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Roles pulled in via meta/main.yml and include_role/import_role
+	deps, err := parser.ParseRoleDependencies(roleInfo.Path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to parse dependencies of %s: %v\n", roleName, err)
+	}
+	roleInfo.Dependencies = deps
+
+	usage, err := parser.ScanRoleUsage(roleInfo.Path, roleName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to scan usage of %s: %v\n", roleName, err)
+	}
+	roleInfo.Usage = usage
+
+	return roleInfo, nil
+}
+
+// roleSources holds the repository files shared by every role of a run,
+// read once by the caller instead of once per role.
+type roleSources struct {
+	playbookTags map[string]parser.PlaybookTags // Install tags by repository type
+	inventory    map[string]string              // Inventory values Jinja defaults resolve against
+}
+
+// loadRoleSources reads the playbooks of both repositories and the
+// inventory. A missing file yields no values; parse errors are annotated on
+// the file and returned.
+func loadRoleSources(cfg *config.Config) (*roleSources, error) {
+	sources := &roleSources{playbookTags: make(map[string]parser.PlaybookTags)}
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		path := cfg.PlaybookPath(repoType)
+		tags, err := parser.ParsePlaybookTags(path)
+		if err != nil {
+			annotator.Add(github.Annotation{Rule: github.RuleParse, File: path, Line: github.ErrorLine(err.Error()), Message: err.Error()})
+			return nil, fmt.Errorf("parsing %s playbook: %w", repoType, err)
+		}
+		sources.playbookTags[repoType] = tags
+	}
+
+	inventory, err := parser.LoadInventoryValues(cfg.InventoryPath())
+	if err != nil {
+		annotator.Add(github.Annotation{Rule: github.RuleParse, File: cfg.InventoryPath(), Line: github.ErrorLine(err.Error()), Message: err.Error()})
+		return nil, fmt.Errorf("parsing inventory %s: %w", cfg.InventoryPath(), err)
+	}
+	sources.inventory = inventory
+
+	return sources, nil
+}
+
+// renderInventory renders role data with the inventory template selected in frontmatter.
+// The base template is loaded together with shared partials and any per-document
+// variants, so a variant only has to override the blocks it changes.
+func renderInventory(cfg *config.Config, data *template.RoleData, fmConfig *docs.SaltboxAutomationConfig) (string, error) {
+	engine, err := loadInventoryEngine(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	name := "inventory"
+	if variant := fmConfig.InventoryTemplate(); variant != "" {
+		name = "inventory/" + variant
+		if !engine.Has(name) {
+			return "", fmt.Errorf("inventory template %q not found in %s", variant, cfg.InventoryVariantsPath())
+		}
+	}
+
+	output, err := engine.Render(name, data)
+	if err != nil {
+		return "", fmt.Errorf("rendering: %w", err)
+	}
+
+	return output, nil
+}
+
+// loadInventoryEngine loads the base inventory template, shared partials and variants.
+func loadInventoryEngine(cfg *config.Config) (*template.Engine, error) {
+	engine := template.New()
+	if err := engine.LoadFile("inventory", cfg.InventoryTemplatePath()); err != nil {
+		return nil, fmt.Errorf("loading template: %w", err)
+	}
+
+	if err := engine.LoadPartials("inventory", filepath.Join(cfg.TemplatePartialsPath(), "*.tmpl")); err != nil {
+		return nil, fmt.Errorf("loading template partials: %w", err)
+	}
+
+	if err := engine.LoadVariants("inventory", filepath.Join(cfg.InventoryVariantsPath(), "*.md.tmpl")); err != nil {
+		return nil, fmt.Errorf("loading template variants: %w", err)
+	}
+
+	return engine, nil
+}
+
 // generateCLIHelp generates CLI help content to stdout.
 func generateCLIHelp(cfg *config.Config) error {
 	binaryPath := cfg.CLIHelp.BinaryPath
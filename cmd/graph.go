@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var graphFormat string
+
+// mermaidIDRe matches characters not allowed in Mermaid node ids.
+var mermaidIDRe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [role]",
+	Short: "Export the role dependency graph",
+	Long: `Export the role dependency graph.
+
+Dependencies are read from each role's meta/main.yml and from include_role /
+import_role tasks, in both the saltbox and sandbox repositories.
+
+With a role argument only the dependency tree of that role is exported.
+Without one, the tree of every role that is not required by another role
+and has dependencies is exported.
+
+Formats:
+  tree     indented text tree (default)
+  dot      Graphviz digraph
+  mermaid  Mermaid flowchart`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		graph, err := buildDependencyGraph(cfg)
+		if err != nil {
+			return err
+		}
+
+		var roots []string
+		if len(args) == 1 {
+			if _, ok := graph[args[0]]; !ok {
+				return fmt.Errorf("role %q not found in saltbox or sandbox", args[0])
+			}
+			roots = []string{args[0]}
+		} else {
+			for _, root := range graph.Roots() {
+				if len(graph[root]) > 0 {
+					roots = append(roots, root)
+				}
+			}
+		}
+
+		switch graphFormat {
+		case "tree":
+			fmt.Print(formatDependencyTree(graph, roots))
+		case "dot":
+			fmt.Print(formatDependencyDOT(graph, roots))
+		case "mermaid":
+			fmt.Print(formatDependencyMermaid(graph, roots))
+		default:
+			return fmt.Errorf("unknown format %q (expected tree, dot or mermaid)", graphFormat)
+		}
+		return nil
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "tree", "output format: tree, dot or mermaid")
+	rootCmd.AddCommand(graphCmd)
+}
+
+// buildDependencyGraph collects the dependencies of every saltbox and sandbox role.
+func buildDependencyGraph(cfg *config.Config) (parser.DependencyGraph, error) {
+	graph := make(parser.DependencyGraph)
+
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		rolesPath := rolesPathFor(cfg, repoType)
+		roles, err := listRoles(rolesPath)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s roles: %w", repoType, err)
+		}
+
+		for _, role := range roles {
+			deps, err := parser.ParseRoleDependencies(filepath.Join(rolesPath, role))
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s dependencies: %w", role, err)
+			}
+			if _, ok := graph[role]; !ok {
+				graph[role] = nil
+			}
+			for _, dep := range deps {
+				graph[role] = appendUnique(graph[role], dep.Name)
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// formatDependencyTree renders the dependency trees of roots as indented text.
+// Roles already on the current path are marked as cycles and not expanded.
+func formatDependencyTree(graph parser.DependencyGraph, roots []string) string {
+	var builder strings.Builder
+
+	var walk func(role, prefix string, path map[string]bool)
+	walk = func(role, prefix string, path map[string]bool) {
+		deps := graph[role]
+		for i, dep := range deps {
+			branch, next := "├── ", "│   "
+			if i == len(deps)-1 {
+				branch, next = "└── ", "    "
+			}
+			if path[dep] {
+				fmt.Fprintf(&builder, "%s%s%s (cycle)\n", prefix, branch, dep)
+				continue
+			}
+			fmt.Fprintf(&builder, "%s%s%s\n", prefix, branch, dep)
+			path[dep] = true
+			walk(dep, prefix+next, path)
+			delete(path, dep)
+		}
+	}
+
+	for _, root := range roots {
+		builder.WriteString(root + "\n")
+		walk(root, "", map[string]bool{root: true})
+	}
+	return builder.String()
+}
+
+// formatDependencyDOT renders the subgraph reachable from roots as a Graphviz digraph.
+func formatDependencyDOT(graph parser.DependencyGraph, roots []string) string {
+	var builder strings.Builder
+	builder.WriteString("digraph roles {\n")
+	builder.WriteString("  rankdir=LR;\n")
+	for _, role := range reachableRoles(graph, roots) {
+		if len(graph[role]) == 0 {
+			fmt.Fprintf(&builder, "  %q;\n", role)
+		}
+		for _, dep := range graph[role] {
+			fmt.Fprintf(&builder, "  %q -> %q;\n", role, dep)
+		}
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// formatDependencyMermaid renders the subgraph reachable from roots as a Mermaid flowchart.
+func formatDependencyMermaid(graph parser.DependencyGraph, roots []string) string {
+	id := func(role string) string {
+		return mermaidIDRe.ReplaceAllString(role, "_") + `["` + role + `"]`
+	}
+
+	var builder strings.Builder
+	builder.WriteString("graph LR\n")
+	for _, role := range reachableRoles(graph, roots) {
+		if len(graph[role]) == 0 {
+			fmt.Fprintf(&builder, "  %s\n", id(role))
+		}
+		for _, dep := range graph[role] {
+			fmt.Fprintf(&builder, "  %s --> %s\n", id(role), id(dep))
+		}
+	}
+	return builder.String()
+}
+
+// reachableRoles returns roots and every role they depend on, directly or not, sorted.
+func reachableRoles(graph parser.DependencyGraph, roots []string) []string {
+	seen := make(map[string]bool)
+	queue := append([]string{}, roots...)
+	for len(queue) > 0 {
+		role := queue[0]
+		queue = queue[1:]
+		if seen[role] {
+			continue
+		}
+		seen[role] = true
+		queue = append(queue, graph[role]...)
+	}
+
+	roles := make([]string, 0, len(seen))
+	for role := range seen {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// appendUnique appends item to items unless it is already present.
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/links"
+	"github.com/spf13/cobra"
+)
+
+var migrateRoleDryRun bool
+
+var migrateRoleCmd = &cobra.Command{
+	Use:   "migrate-role <old> <new>",
+	Short: "Migrate the documentation of a renamed role",
+	Long: `Migrate the documentation of a role that was renamed, e.g. organizr to organizr2.
+
+The migration:
+  - renames the doc file (<old>.md to <new>.md, or the path_overrides file)
+  - rewrites <old>_ variable prefixes in inventory.example_overrides
+  - updates config.yml: the docs_coverage blacklist, the path_overrides key
+    and type_inference keys naming <old>_ variables
+  - rewrites relative links to the doc across the docs tree
+  - runs update on the new doc when the <new> role exists
+
+The plan is always printed first; with --dry-run nothing is changed.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return migrateRole(cfg, args[0], args[1])
+	},
+}
+
+func init() {
+	migrateRoleCmd.Flags().BoolVar(&migrateRoleDryRun, "dry-run", false, "print the migration plan without applying it")
+	rootCmd.AddCommand(migrateRoleCmd)
+}
+
+// migrateRole renames the documentation of oldRole to newRole.
+func migrateRole(cfg *config.Config, oldRole, newRole string) error {
+	if oldRole == newRole {
+		return fmt.Errorf("old and new role names are the same")
+	}
+
+	// Locate the existing doc
+	repoType, oldDoc := "", ""
+	for _, rt := range []string{"saltbox", "sandbox"} {
+		if path := getDocPath(cfg, oldRole, rt); path != "" {
+			if _, err := os.Stat(path); err == nil {
+				repoType, oldDoc = rt, path
+				break
+			}
+		}
+	}
+	if oldDoc == "" {
+		return fmt.Errorf("no documentation found for role %q", oldRole)
+	}
+
+	newDoc := migratedDocPath(cfg, repoType, oldRole, newRole)
+	if newDoc != oldDoc {
+		if _, err := os.Stat(newDoc); err == nil {
+			return fmt.Errorf("%s already exists", newDoc)
+		}
+	}
+
+	configPath := GetConfigPath()
+	rawConfig, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("reading config: %w", err)
+	}
+	newConfig, configChanges, err := config.RenameRole(string(rawConfig), repoType, oldRole, newRole)
+	if err != nil {
+		return fmt.Errorf("updating config: %w", err)
+	}
+
+	var refs []links.BrokenRef
+	if newDoc != oldDoc {
+		checker, err := newRefChecker(cfg)
+		if err != nil {
+			return err
+		}
+		refs = checker.Retarget(oldDoc, newDoc)
+	}
+
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+	doc, err := manager.LoadDocument(oldDoc)
+	if err != nil {
+		return err
+	}
+
+	// Links inside the doc itself are rewritten before the frontmatter
+	// edit, which may shift line numbers
+	byFile := make(map[string][]links.BrokenRef)
+	var files []string
+	for _, ref := range refs {
+		if _, ok := byFile[ref.File]; !ok {
+			files = append(files, ref.File)
+		}
+		byFile[ref.File] = append(byFile[ref.File], ref)
+	}
+	if own := byFile[oldDoc]; len(own) > 0 {
+		updated, _ := links.ApplySuggestions(doc.Content, own)
+		fm, body, err := docs.ParseFrontmatter(updated)
+		if err != nil {
+			return fmt.Errorf("%s: %w", oldDoc, err)
+		}
+		doc.Content, doc.Frontmatter, doc.Body = updated, fm, body
+	}
+	renames, err := doc.RenameExampleOverrides(oldRole, newRole)
+	if err != nil {
+		return fmt.Errorf("%s: %w", oldDoc, err)
+	}
+
+	newRoleExists := false
+	if info, err := os.Stat(filepath.Join(rolesPathFor(cfg, repoType), newRole)); err == nil && info.IsDir() {
+		newRoleExists = true
+	}
+
+	// Plan
+	fmt.Printf("Migration plan: %s → %s (%s)\n", oldRole, newRole, repoType)
+	if newDoc != oldDoc {
+		fmt.Printf("  📄 rename %s → %s\n", relDocsPath(cfg, oldDoc), relDocsPath(cfg, newDoc))
+	}
+	for _, rename := range renames {
+		fmt.Printf("  ✏️  example_overrides: %s → %s\n", rename.Old, rename.New)
+	}
+	for _, change := range configChanges {
+		fmt.Printf("  ⚙️  %s: %s\n", configPath, change)
+	}
+	for _, ref := range refs {
+		fmt.Printf("  🔗 %s:%d: %s → %s\n", relDocsPath(cfg, ref.File), ref.Line, ref.Target, ref.Suggestion)
+	}
+	if newRoleExists {
+		fmt.Printf("  🔄 update %s\n", newRole)
+	} else {
+		fmt.Printf("  ⚠️  role %q not found in %s roles, skipping update\n", newRole, repoType)
+	}
+
+	if migrateRoleDryRun {
+		return nil
+	}
+
+	// Apply
+	for _, file := range files {
+		if file == oldDoc {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		updated, _ := links.ApplySuggestions(string(content), byFile[file])
+		if err := os.WriteFile(file, []byte(updated), 0644); err != nil {
+			return err
+		}
+	}
+
+	doc.Path = newDoc
+	if err := os.MkdirAll(filepath.Dir(newDoc), 0755); err != nil {
+		return err
+	}
+	if err := manager.SaveDocument(doc); err != nil {
+		return fmt.Errorf("saving document: %w", err)
+	}
+	if newDoc != oldDoc {
+		if err := os.Remove(oldDoc); err != nil {
+			return err
+		}
+	}
+
+	if len(configChanges) > 0 {
+		if err := os.WriteFile(configPath, []byte(newConfig), 0644); err != nil {
+			return fmt.Errorf("writing config: %w", err)
+		}
+	}
+	fmt.Printf("✅ Migrated %s to %s\n", oldRole, newRole)
+
+	if !newRoleExists {
+		return nil
+	}
+	cfg, err = config.Load(configPath)
+	if err != nil {
+		return fmt.Errorf("reloading config: %w", err)
+	}
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return err
+	}
+	if err := updateRoleWithType(cfg, sources, newRole, repoType); err != nil {
+		var skipErr *skipError
+		if errors.As(err, &skipErr) {
+			fmt.Printf("Skipping update of %s: %s\n", newRole, skipErr.reason)
+			return nil
+		}
+		return fmt.Errorf("updating %s: %w", newRole, err)
+	}
+	fmt.Printf("✅ Updated %s\n", newDoc)
+	return nil
+}
+
+// migratedDocPath returns the doc path of oldRole's documentation after a
+// rename to newRole, following path_overrides.
+func migratedDocPath(cfg *config.Config, repoType, oldRole, newRole string) string {
+	if override, ok := cfg.PathOverrides[repoType][oldRole]; ok {
+		return filepath.Join(cfg.Repositories.Docs, config.RenamedOverride(override, oldRole, newRole))
+	}
+	return filepath.Join(filepath.Dir(getDocPath(cfg, oldRole, repoType)), newRole+".md")
+}
+
+// relDocsPath returns a path relative to the docs repo for display.
+func relDocsPath(cfg *config.Config, path string) string {
+	if rel, err := filepath.Rel(cfg.Repositories.Docs, path); err == nil {
+		return rel
+	}
+	return path
+}
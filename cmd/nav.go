@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	navGroupBy string
+	navCheck   bool
+)
+
+var navCmd = &cobra.Command{
+	Use:   "nav",
+	Short: "Update the MkDocs nav with the app docs",
+	Long: `Update the app sections of the MkDocs nav from the docs that exist.
+
+The saltbox and sandbox app docs are listed (skipping blacklisted roles and
+index pages) with their title: the frontmatter title, else the first
+level 1 heading, else the role name. They replace the items of the nav
+sections configured under nav.saltbox and nav.sandbox (default "Apps" and
+"Sandbox > Apps"), which are created when missing. The whole item list of
+those sections is managed.
+
+Entries are alphabetized, or grouped by their first project_description
+category with --group-by category (apps without one go under "Other").
+
+Only the nav entry of mkdocs.yml is rewritten; the rest of the file stays
+byte-identical. With --check nothing is written and the command fails when
+the nav is out of date.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		groupBy := cfg.Nav.GroupBy
+		if navGroupBy != "" {
+			groupBy = navGroupBy
+		}
+		if groupBy != "" && groupBy != docs.NavGroupAlpha && groupBy != docs.NavGroupCategory {
+			return fmt.Errorf("unknown --group-by %q (expected alpha or category)", groupBy)
+		}
+
+		return updateNav(cfg, groupBy)
+	},
+}
+
+func init() {
+	navCmd.Flags().StringVar(&navGroupBy, "group-by", "", "alpha or category (default: nav.group_by)")
+	navCmd.Flags().BoolVar(&navCheck, "check", false, "fail if the nav is out of date instead of writing it")
+	rootCmd.AddCommand(navCmd)
+}
+
+// updateNav rewrites the managed nav sections of mkdocs.yml.
+func updateNav(cfg *config.Config, groupBy string) error {
+	mkdocsPath := cfg.MkDocsConfigPath()
+	raw, err := os.ReadFile(mkdocsPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", mkdocsPath, err)
+	}
+	content := string(raw)
+	docsDir := filepath.Join(filepath.Dir(mkdocsPath), mkdocsDocsDir(raw))
+
+	changed := false
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		entries, err := navEntries(cfg, repoType, docsDir)
+		if err != nil {
+			return err
+		}
+
+		titles := cfg.NavPath(repoType)
+		updated, sectionChanged, err := docs.UpdateNav(content, titles, docs.BuildNav(entries, groupBy))
+		if err != nil {
+			return err
+		}
+		if sectionChanged {
+			fmt.Printf("📝 %s: %d apps\n", strings.Join(titles, " > "), len(entries))
+			content = updated
+			changed = true
+		}
+	}
+
+	if !changed {
+		fmt.Println("✅ Nav is up to date")
+		return nil
+	}
+	if navCheck {
+		return fmt.Errorf("nav in %s is out of date, run sb-docs nav", mkdocsPath)
+	}
+	if err := os.WriteFile(mkdocsPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("writing %s: %w", mkdocsPath, err)
+	}
+	fmt.Printf("✅ Updated %s\n", mkdocsPath)
+	return nil
+}
+
+// navEntries lists the app docs of a repo type as nav entries.
+func navEntries(cfg *config.Config, repoType, docsDir string) ([]docs.NavEntry, error) {
+	dir := cfg.SaltboxDocsPath()
+	blacklist := cfg.Blacklist.DocsCoverage.Saltbox
+	if repoType == "sandbox" {
+		dir = cfg.SandboxDocsPath()
+		blacklist = cfg.Blacklist.DocsCoverage.Sandbox
+	}
+	blacklisted := make(map[string]bool)
+	for _, role := range blacklist {
+		blacklisted[role] = true
+	}
+
+	files, err := docs.ListDocFiles(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("listing %s docs: %w", repoType, err)
+	}
+
+	manager := docs.NewManager(docs.MarkerConfig{})
+	var entries []docs.NavEntry
+	for _, path := range files {
+		role := docs.ExtractRoleName(path)
+		if blacklisted[role] {
+			continue
+		}
+		doc, err := manager.LoadDocument(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rel, err := filepath.Rel(docsDir, path)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, docs.NavEntry{
+			Title:    docs.DocumentTitle(doc, role),
+			Path:     filepath.ToSlash(rel),
+			Category: docs.DocumentCategory(doc),
+		})
+	}
+	return entries, nil
+}
+
+// mkdocsDocsDir returns the docs_dir of an MkDocs config, "docs" by default.
+func mkdocsDocsDir(content []byte) string {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil || len(root.Content) == 0 {
+		return "docs"
+	}
+	if value := docs.MappingGet(root.Content[0], "docs_dir"); value != nil && value.Value != "" {
+		return value.Value
+	}
+	return "docs"
+}
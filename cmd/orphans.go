@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/links"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultOrphanNotice is the deprecation admonition of archived docs.
+const defaultOrphanNotice = "This app has been removed and this page is no longer maintained."
+
+// Orphan plan actions.
+const (
+	orphanActionArchive = "archive"
+	orphanActionSkip    = "skip"
+)
+
+var (
+	fixOrphansPlan  string
+	fixOrphansApply bool
+)
+
+var fixCmd = &cobra.Command{
+	Use:   "fix",
+	Short: "Remediate coverage check findings",
+	Long:  "Remediate findings of the coverage checks.",
+}
+
+var fixOrphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "Archive docs whose role no longer exists",
+	Long: `Archive orphaned docs: docs whose role no longer exists.
+
+Without --apply, the orphaned docs are written to a plan file
+(orphans.plan_file, default orphans-plan.yml) with the action "archive".
+Review it, change the action of docs to keep to "skip", then run with
+--apply. Actions already in the plan are kept when it is regenerated.
+
+Applying the plan moves each doc into the archive folder (orphans.archive_dir,
+default docs/archive) keeping its path below docs/, adds a deprecation
+admonition below its heading, rewrites links to and from the page, and maps
+the old page to the archived one in the mkdocs-redirects plugin of
+mkdocs.yml so external links keep working.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		planPath := cfg.Orphans.PlanFile
+		if fixOrphansPlan != "" {
+			planPath = fixOrphansPlan
+		}
+		if planPath == "" {
+			planPath = "orphans-plan.yml"
+		}
+
+		if fixOrphansApply {
+			return applyOrphanPlan(cfg, planPath)
+		}
+		return writeOrphanPlan(cfg, planPath)
+	},
+}
+
+func init() {
+	fixOrphansCmd.Flags().StringVar(&fixOrphansPlan, "plan", "", "plan file (default: orphans.plan_file)")
+	fixOrphansCmd.Flags().BoolVar(&fixOrphansApply, "apply", false, "apply the reviewed plan")
+	fixCmd.AddCommand(fixOrphansCmd)
+	rootCmd.AddCommand(fixCmd)
+}
+
+// orphanedDoc is a doc without a corresponding role.
+type orphanedDoc struct {
+	Name     string // Role name, prefixed with "sandbox/" for sandbox docs
+	Role     string
+	RepoType string
+	Path     string
+}
+
+// listOrphanedDocs returns the docs of the saltbox and sandbox app folders
+// that match no role, skipping blacklisted names and path_overrides targets.
+// Results are sorted by name.
+func listOrphanedDocs(cfg *config.Config) ([]orphanedDoc, error) {
+	// Build set of doc names that are targets of path overrides
+	overrideTargets := make(map[string]bool)
+	for _, repoOverrides := range cfg.PathOverrides {
+		for _, overridePath := range repoOverrides {
+			fullPath := filepath.Join(cfg.Repositories.Docs, overridePath)
+			if _, err := os.Stat(fullPath); err == nil {
+				baseName := strings.TrimSuffix(filepath.Base(overridePath), ".md")
+				overrideTargets[baseName] = true
+			}
+		}
+	}
+
+	var orphans []orphanedDoc
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		docsPath, blacklist, prefix := cfg.SaltboxDocsPath(), cfg.Blacklist.DocsCoverage.Saltbox, ""
+		if repoType == "sandbox" {
+			docsPath, blacklist, prefix = cfg.SandboxDocsPath(), cfg.Blacklist.DocsCoverage.Sandbox, "sandbox/"
+		}
+
+		roles, err := listRoles(rolesPathFor(cfg, repoType))
+		if err != nil {
+			return nil, fmt.Errorf("listing %s roles: %w", repoType, err)
+		}
+		roleSet := make(map[string]bool)
+		for _, role := range roles {
+			roleSet[role] = true
+		}
+		blacklisted := make(map[string]bool)
+		for _, role := range blacklist {
+			blacklisted[role] = true
+		}
+
+		files, err := docs.ListDocFiles(docsPath)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s docs: %w", repoType, err)
+		}
+		for _, path := range files {
+			name := docs.ExtractRoleName(path)
+			if blacklisted[name] || overrideTargets[name] || roleSet[name] {
+				continue
+			}
+			orphans = append(orphans, orphanedDoc{Name: prefix + name, Role: name, RepoType: repoType, Path: path})
+		}
+	}
+
+	sort.Slice(orphans, func(i, j int) bool {
+		return orphans[i].Name < orphans[j].Name
+	})
+	return orphans, nil
+}
+
+// orphanPlan is the reviewable plan of fix orphans.
+type orphanPlan struct {
+	Orphans []orphanPlanEntry `yaml:"orphans"`
+}
+
+// orphanPlanEntry is one orphaned doc of the plan. Paths are relative to the docs repo.
+type orphanPlanEntry struct {
+	Role    string `yaml:"role"`
+	Repo    string `yaml:"repo"`
+	Doc     string `yaml:"doc"`
+	Archive string `yaml:"archive"`
+	Action  string `yaml:"action"` // archive or skip
+}
+
+// writeOrphanPlan writes the plan for the current orphaned docs.
+func writeOrphanPlan(cfg *config.Config, planPath string) error {
+	orphans, err := listOrphanedDocs(cfg)
+	if err != nil {
+		return err
+	}
+	if len(orphans) == 0 {
+		fmt.Println("✅ No orphaned docs")
+		return nil
+	}
+
+	// Keep reviewed actions of an existing plan
+	previous := make(map[string]string)
+	if existing, err := loadOrphanPlan(planPath); err == nil {
+		for _, entry := range existing.Orphans {
+			previous[entry.Doc] = entry.Action
+		}
+	}
+
+	var plan orphanPlan
+	for _, orphan := range orphans {
+		archive := orphanArchivePath(cfg, orphan.Path)
+		entry := orphanPlanEntry{
+			Role:    orphan.Role,
+			Repo:    orphan.RepoType,
+			Doc:     relDocsPath(cfg, orphan.Path),
+			Archive: relDocsPath(cfg, archive),
+			Action:  orphanActionArchive,
+		}
+		if action, ok := previous[entry.Doc]; ok {
+			entry.Action = action
+		}
+		plan.Orphans = append(plan.Orphans, entry)
+		fmt.Printf("  📦 %s: %s → %s (%s)\n", orphan.Name, entry.Doc, entry.Archive, entry.Action)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Orphaned docs to archive. Set action to \"skip\" to keep a doc in place,\n" +
+		"# then run: sb-docs fix orphans --apply\n")
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&plan); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(planPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing plan: %w", err)
+	}
+	fmt.Printf("📝 Wrote plan for %d orphaned doc(s) to %s\n", len(orphans), planPath)
+	return nil
+}
+
+// loadOrphanPlan reads a plan file.
+func loadOrphanPlan(planPath string) (*orphanPlan, error) {
+	data, err := os.ReadFile(planPath)
+	if err != nil {
+		return nil, err
+	}
+	var plan orphanPlan
+	if err := yaml.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", planPath, err)
+	}
+	return &plan, nil
+}
+
+// applyOrphanPlan archives the docs of a reviewed plan and registers redirects.
+func applyOrphanPlan(cfg *config.Config, planPath string) error {
+	plan, err := loadOrphanPlan(planPath)
+	if err != nil {
+		return fmt.Errorf("loading plan: %w", err)
+	}
+	for _, entry := range plan.Orphans {
+		if entry.Action != orphanActionArchive && entry.Action != orphanActionSkip {
+			return fmt.Errorf("%s: unknown action %q (expected archive or skip)", entry.Doc, entry.Action)
+		}
+		if entry.Doc == "" || entry.Archive == "" {
+			return fmt.Errorf("plan entry for %q is missing doc or archive", entry.Role)
+		}
+	}
+
+	mkdocsPath := cfg.MkDocsConfigPath()
+	mkdocs, err := os.ReadFile(mkdocsPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", mkdocsPath, err)
+	}
+	docsDir := filepath.Join(filepath.Dir(mkdocsPath), mkdocsDocsDir(mkdocs))
+
+	notice := cfg.Orphans.Notice
+	if notice == "" {
+		notice = defaultOrphanNotice
+	}
+
+	redirects := make(map[string]string)
+	archived := 0
+	for _, entry := range plan.Orphans {
+		if entry.Action == orphanActionSkip {
+			fmt.Printf("⏭️  %s: skipped\n", entry.Doc)
+			continue
+		}
+		oldPath := filepath.Join(cfg.Repositories.Docs, entry.Doc)
+		newPath := filepath.Join(cfg.Repositories.Docs, entry.Archive)
+		if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+			fmt.Printf("⏭️  %s: no longer exists\n", entry.Doc)
+			continue
+		}
+
+		count, err := archiveDoc(cfg, oldPath, newPath, notice)
+		if err != nil {
+			return fmt.Errorf("archiving %s: %w", entry.Doc, err)
+		}
+		fmt.Printf("📦 %s → %s (%d link(s) rewritten)\n", entry.Doc, entry.Archive, count)
+		archived++
+
+		from, errFrom := filepath.Rel(docsDir, oldPath)
+		to, errTo := filepath.Rel(docsDir, newPath)
+		if errFrom != nil || errTo != nil {
+			continue
+		}
+		redirects[filepath.ToSlash(from)] = filepath.ToSlash(to)
+	}
+
+	if len(redirects) > 0 {
+		updated, changed, err := docs.AddRedirects(string(mkdocs), redirects)
+		if err != nil {
+			return fmt.Errorf("adding redirects to %s: %w", mkdocsPath, err)
+		}
+		if changed {
+			if err := os.WriteFile(mkdocsPath, []byte(updated), 0644); err != nil {
+				return fmt.Errorf("writing %s: %w", mkdocsPath, err)
+			}
+			fmt.Printf("🔀 Added %d redirect(s) to %s\n", len(redirects), mkdocsPath)
+		}
+	}
+
+	fmt.Printf("✅ Archived %d doc(s)\n", archived)
+	if archived > 0 {
+		fmt.Println("Run sb-docs nav to refresh the app navigation.")
+	}
+	return nil
+}
+
+// archiveDoc moves a doc to newPath with a deprecation notice, rewriting
+// links to and from it. It returns the number of links rewritten.
+func archiveDoc(cfg *config.Config, oldPath, newPath, notice string) (int, error) {
+	if _, err := os.Stat(newPath); err == nil {
+		return 0, fmt.Errorf("%s already exists", newPath)
+	}
+
+	checker, err := newRefChecker(cfg)
+	if err != nil {
+		return 0, err
+	}
+	inbound := checker.Retarget(oldPath, newPath)
+	own := checker.Rebase(oldPath, newPath)
+
+	byFile := make(map[string][]links.BrokenRef)
+	for _, ref := range inbound {
+		if ref.File == oldPath {
+			own = append(own, ref)
+			continue
+		}
+		byFile[ref.File] = append(byFile[ref.File], ref)
+	}
+
+	count := 0
+	for file, refs := range byFile {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return count, err
+		}
+		updated, n := links.ApplySuggestions(string(content), refs)
+		if err := os.WriteFile(file, []byte(updated), 0644); err != nil {
+			return count, err
+		}
+		count += n
+	}
+
+	content, err := os.ReadFile(oldPath)
+	if err != nil {
+		return count, err
+	}
+	updated, n := links.ApplySuggestions(string(content), own)
+	count += n
+	updated, _ = docs.AddDeprecationNotice(updated, notice)
+
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		return count, err
+	}
+	if err := os.WriteFile(newPath, []byte(updated), 0644); err != nil {
+		return count, err
+	}
+	return count, os.Remove(oldPath)
+}
+
+// orphanArchivePath returns where an orphaned doc is archived: its path
+// below the docs directory, inside the archive folder.
+func orphanArchivePath(cfg *config.Config, docPath string) string {
+	rel, err := filepath.Rel(filepath.Join(cfg.Repositories.Docs, "docs"), docPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = filepath.Base(docPath)
+	}
+	return filepath.Join(cfg.OrphanArchivePath(), rel)
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/github"
+	"github.com/spf13/cobra"
+)
+
+var (
+	prReportDryRun bool
+	prReportNumber int
+	prReportRepo   string
+)
+
+var prReportCmd = &cobra.Command{
+	Use:   "pr-report [role...]",
+	Short: "Comment the documentation impact of a pull request",
+	Long: `Render the docs of all roles (or the given roles) in memory from the
+checked out Saltbox and Sandbox repos, compare them with the managed
+sections currently in the docs repo, and post the result on the pull
+request: per changed role the added, removed and changed variables and a
+collapsed diff of its doc. Nothing is written to the docs repo.
+
+The comment is identified by a hidden marker and updated in place on later
+runs. When nothing changes and no comment exists yet, none is posted.
+
+The pull request and repository are read from the event payload in
+GITHUB_EVENT_PATH (a pull_request or pull_request_target workflow), or
+from --pr and --repo. The comment is posted through the GitHub REST API
+with GITHUB_TOKEN (or GH_TOKEN). Use --dry-run to print it instead.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return prReport(cfg, args)
+	},
+}
+
+func init() {
+	prReportCmd.Flags().BoolVar(&prReportDryRun, "dry-run", false, "print the comment instead of posting it")
+	prReportCmd.Flags().IntVar(&prReportNumber, "pr", 0, "pull request number (default: from GITHUB_EVENT_PATH)")
+	prReportCmd.Flags().StringVar(&prReportRepo, "repo", "", "repository of the pull request as owner/repo (default: from GITHUB_EVENT_PATH)")
+	rootCmd.AddCommand(prReportCmd)
+}
+
+// prReport renders the roles, builds the impact comment and posts it.
+func prReport(cfg *config.Config, roles []string) error {
+	impacts, failed, err := collectRoleImpacts(cfg, roles)
+	if err != nil {
+		return err
+	}
+	body := github.GeneratePRReport(impacts, failed)
+
+	if prReportDryRun {
+		fmt.Print(body)
+		return nil
+	}
+
+	number, repo := prReportNumber, prReportRepo
+	if number == 0 || repo == "" {
+		eventPath := os.Getenv("GITHUB_EVENT_PATH")
+		if eventPath == "" {
+			return fmt.Errorf("no pull request: set GITHUB_EVENT_PATH or --pr and --repo")
+		}
+		event, err := github.LoadPullRequestEvent(eventPath)
+		if err != nil {
+			return err
+		}
+		if number == 0 {
+			number = event.Number
+		}
+		if repo == "" {
+			repo = event.Repo
+		}
+	}
+
+	token := github.Token()
+	if token == "" {
+		return fmt.Errorf("GITHUB_TOKEN is not set")
+	}
+	apiURL := cfg.ForgeAPIURL()
+	if apiURL == "" {
+		apiURL = os.Getenv("GITHUB_API_URL")
+	}
+	if apiURL == "" {
+		apiURL = github.DefaultAPIURL
+	}
+	client := github.NewRESTClient(repo, token, apiURL)
+
+	existing, err := client.FindComment(number, github.PRReportMarker)
+	if err != nil {
+		return fmt.Errorf("finding existing comment: %w", err)
+	}
+
+	switch {
+	case existing != nil && existing.Body == body:
+		fmt.Printf("✅ Comment on #%d is up to date\n", number)
+	case existing != nil:
+		if err := client.UpdateComment(existing.ID, body); err != nil {
+			return fmt.Errorf("updating comment: %w", err)
+		}
+		fmt.Printf("✏️ Updated comment on #%d (%d role(s) affected)\n", number, len(impacts))
+	case len(impacts) == 0 && len(failed) == 0:
+		fmt.Printf("✅ No documentation impact on #%d\n", number)
+	default:
+		if err := client.AddComment(number, body); err != nil {
+			return fmt.Errorf("adding comment: %w", err)
+		}
+		fmt.Printf("📝 Commented on #%d (%d role(s) affected)\n", number, len(impacts))
+	}
+
+	return nil
+}
+
+// collectRoleImpacts renders roles in memory and returns the changes to
+// their docs and the roles that failed to render. All roles that are not
+// blacklisted are rendered when roles is empty.
+func collectRoleImpacts(cfg *config.Config, roles []string) ([]github.RoleImpact, []github.RoleResult, error) {
+	type roleRef struct{ name, repoType string }
+	var refs []roleRef
+
+	if len(roles) > 0 {
+		for _, role := range roles {
+			repoType := "saltbox"
+			if info, err := os.Stat(filepath.Join(cfg.SaltboxRolesPath(), role)); err != nil || !info.IsDir() {
+				repoType = "sandbox"
+				if info, err := os.Stat(filepath.Join(cfg.SandboxRolesPath(), role)); err != nil || !info.IsDir() {
+					return nil, nil, fmt.Errorf("role %q not found in saltbox or sandbox", role)
+				}
+			}
+			refs = append(refs, roleRef{role, repoType})
+		}
+	} else {
+		for _, repoType := range []string{"saltbox", "sandbox"} {
+			names, err := listRoles(rolesPathFor(cfg, repoType))
+			if err != nil {
+				return nil, nil, fmt.Errorf("listing %s roles: %w", repoType, err)
+			}
+			blacklist := cfg.Blacklist.DocsCoverage.Saltbox
+			if repoType == "sandbox" {
+				blacklist = cfg.Blacklist.DocsCoverage.Sandbox
+			}
+			for _, name := range filterBlacklist(names, blacklist) {
+				refs = append(refs, roleRef{name, repoType})
+			}
+		}
+	}
+
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var impacts []github.RoleImpact
+	var failed []github.RoleResult
+	for _, ref := range refs {
+		result, doc, original := renderRole(cfg, manager, sources, ref.name, ref.repoType)
+		if result.Status == github.StatusError {
+			failed = append(failed, result)
+			continue
+		}
+		if doc == nil || doc.Content == original {
+			continue
+		}
+
+		recordRoleChanges(cfg, &result, original, doc.Content)
+		impact := github.RoleImpact{
+			Name:     ref.name,
+			RepoType: ref.repoType,
+			Doc:      relDocsPath(cfg, doc.Path),
+			Added:    result.Added,
+			Removed:  result.Removed,
+			Changed:  result.Changed,
+			Diff:     result.Diff,
+		}
+
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Changed: %s (%s)\n", ref.name, ref.repoType)
+		}
+		impacts = append(impacts, impact)
+	}
+
+	return impacts, failed, nil
+}
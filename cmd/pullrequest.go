@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/git"
+	"github.com/saltyorg/docs-automation/internal/github"
+)
+
+// openDocsPullRequest commits the docs written by an update run to the
+// rolling update branch and opens its pull request, or updates the one
+// already open. Only the files the run changed are committed, on top of the
+// checked out commit; the checkout itself is left untouched.
+func openDocsPullRequest(cfg *config.Config, summary *github.UpdateSummary, backend string) error {
+	var files []string
+	for _, r := range summary.Roles {
+		if r.Status == github.StatusUpdated {
+			files = append(files, r.Doc)
+		}
+	}
+	if summary.CLIUpdated {
+		files = append(files, filepath.Join(cfg.Repositories.Docs, cfg.CLIHelp.DocsFile))
+	}
+	if len(files) == 0 {
+		fmt.Println("✅ No documentation changes to propose")
+		return nil
+	}
+
+	repo, err := git.Open(cfg.Repositories.Docs)
+	if err != nil {
+		return fmt.Errorf("opening docs repo: %w", err)
+	}
+	repo.Author = cfg.PullRequest.Author
+
+	base := cfg.PullRequest.Base
+	if base == "" {
+		if base, err = repo.CurrentBranch(); err != nil {
+			return fmt.Errorf("determining base branch (set pull_request.base): %w", err)
+		}
+	}
+	head := cfg.PullRequestBranch()
+
+	message := summary.CommitMessage()
+	commit, err := repo.CommitFiles("HEAD", files, message)
+	if err != nil {
+		return fmt.Errorf("committing changes: %w", err)
+	}
+	if commit == "" {
+		fmt.Println("✅ No documentation changes to propose")
+		return nil
+	}
+	if err := repo.Push(cfg.PullRequestRemote(), commit, head); err != nil {
+		return fmt.Errorf("pushing %s: %w", head, err)
+	}
+	fmt.Printf("📦 Pushed %d file(s) to %s\n", len(files), head)
+
+	forge, err := newForge(cfg, backend)
+	if err != nil {
+		return err
+	}
+	title, _, _ := strings.Cut(message, "\n")
+	pr, created, err := github.UpsertPullRequest(forge, head, base, title, summary.PullRequestBody())
+	if err != nil {
+		return err
+	}
+	if created {
+		fmt.Printf("🔀 Opened pull request #%d: %s\n", pr.Number, pr.URL)
+	} else {
+		fmt.Printf("🔀 Updated pull request #%d: %s\n", pr.Number, pr.URL)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/links"
+	"github.com/spf13/cobra"
+)
+
+var validateRefsFix bool
+
+var validateRefsCmd = &cobra.Command{
+	Use:   "refs",
+	Short: "Validate internal links and anchors in the docs",
+	Long: `Validate relative links and #anchors across the docs tree.
+
+Every markdown link, image and reference definition outside code blocks is
+resolved relative to its file (or to the docs directory for absolute paths).
+Anchors are matched against MkDocs-style heading slugs, { #id } attributes
+and HTML ids of the target page.
+
+When a linked page is missing but the page was moved by path_overrides, or
+exactly one page with the same file name exists elsewhere, a rewrite is
+suggested; --fix applies the suggestions in place.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return validateRefs(cfg)
+	},
+}
+
+func init() {
+	validateRefsCmd.Flags().BoolVar(&validateRefsFix, "fix", false, "rewrite links to moved pages")
+	validateCmd.AddCommand(validateRefsCmd)
+}
+
+// validateRefs checks internal links across the docs tree.
+func validateRefs(cfg *config.Config) error {
+	checker, err := newRefChecker(cfg)
+	if err != nil {
+		return err
+	}
+
+	broken := checker.Check()
+	byFile := make(map[string][]links.BrokenRef)
+	var files []string
+	remaining := 0
+	for _, ref := range broken {
+		if _, ok := byFile[ref.File]; !ok {
+			files = append(files, ref.File)
+		}
+		byFile[ref.File] = append(byFile[ref.File], ref)
+
+		rel, err := filepath.Rel(cfg.Repositories.Docs, ref.File)
+		if err != nil {
+			rel = ref.File
+		}
+		if ref.Suggestion != "" {
+			fmt.Printf("❌ %s:%d: %s (%s) → %s\n", rel, ref.Line, ref.Target, ref.Reason, ref.Suggestion)
+		} else {
+			fmt.Printf("❌ %s:%d: %s (%s)\n", rel, ref.Line, ref.Target, ref.Reason)
+		}
+		if !validateRefsFix || ref.Suggestion == "" {
+			remaining++
+		}
+	}
+
+	if validateRefsFix {
+		fixed := 0
+		for _, file := range files {
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			updated, count := links.ApplySuggestions(string(content), byFile[file])
+			if count == 0 {
+				continue
+			}
+			if err := os.WriteFile(file, []byte(updated), 0644); err != nil {
+				return err
+			}
+			fixed += count
+		}
+		if fixed > 0 {
+			fmt.Printf("✏️  Rewrote %d link(s)\n", fixed)
+		}
+	}
+
+	if remaining > 0 {
+		return fmt.Errorf("found %d broken internal link(s)", remaining)
+	}
+	if len(broken) == 0 {
+		fmt.Println("✅ All internal links resolve")
+	}
+	return nil
+}
+
+// newRefChecker indexes the docs directory and records pages moved by path_overrides.
+func newRefChecker(cfg *config.Config) (*links.RefChecker, error) {
+	checker, err := links.NewRefChecker(filepath.Join(cfg.Repositories.Docs, "docs"))
+	if err != nil {
+		return nil, fmt.Errorf("indexing docs: %w", err)
+	}
+
+	for repoType, overrides := range cfg.PathOverrides {
+		defaultDir := cfg.SaltboxDocsPath()
+		if repoType == "sandbox" {
+			defaultDir = cfg.SandboxDocsPath()
+		}
+		for role, override := range overrides {
+			oldPath := filepath.Join(defaultDir, role+".md")
+			newPath := filepath.Join(cfg.Repositories.Docs, override)
+			if _, err := os.Stat(oldPath); err == nil {
+				continue
+			}
+			checker.AddMove(oldPath, newPath)
+		}
+	}
+	return checker, nil
+}
@@ -1,16 +1,24 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
+	"github.com/saltyorg/docs-automation/internal/github"
+	"github.com/saltyorg/docs-automation/internal/runtime"
 	"github.com/spf13/cobra"
 )
 
 var (
-	cfgFile string
-	verbose bool
+	cfgFile   string
+	verbose   bool
+	sarifFile string
 )
 
+// annotator collects the problems found by commands, printed as workflow
+// commands in GitHub Actions and written to the --sarif file.
+var annotator = github.NewAnnotator()
+
 // rootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
 	Use:   "sb-docs",
@@ -28,7 +36,17 @@ It performs the following core functions:
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+
+	// Write the SARIF log even when the command failed, as problems fail it
+	if sarifFile != "" {
+		if sarifErr := annotator.WriteSARIF(sarifFile, runtime.Version); sarifErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: writing SARIF: %v\n", sarifErr)
+			os.Exit(1)
+		}
+	}
+
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -36,6 +54,7 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "config.yml", "config file path")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&sarifFile, "sarif", "", "write the problems found to a SARIF file for code scanning upload")
 }
 
 // GetConfigPath returns the configured config file path.
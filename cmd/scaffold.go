@@ -8,9 +8,9 @@ import (
 	"text/template"
 
 	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/funcs"
+	"github.com/saltyorg/docs-automation/internal/parser"
 	"github.com/spf13/cobra"
-	"golang.org/x/text/cases"
-	"golang.org/x/text/language"
 )
 
 var (
@@ -48,11 +48,12 @@ func init() {
 
 // ScaffoldData contains data for the scaffold template.
 type ScaffoldData struct {
-	RoleName  string // e.g., "sonarr"
-	RoleTitle string // e.g., "Sonarr" (title case)
-	RoleTag   string // e.g., "sonarr" (for install command)
-	RepoType  string // "saltbox" or "sandbox"
-	TagPrefix string // "" for saltbox, "sandbox-" for sandbox
+	RoleName  string   // e.g., "sonarr"
+	RoleTitle string   // e.g., "Sonarr" (title case)
+	RoleTag   string   // e.g., "sonarr" (for install command)
+	Tags      []string // All playbook tags the role is included with
+	RepoType  string   // "saltbox" or "sandbox"
+	TagPrefix string   // "" for saltbox, "sandbox-" for sandbox
 }
 
 // scaffoldRole creates a new documentation file for a role.
@@ -85,17 +86,23 @@ func scaffoldRole(cfg *config.Config, roleName string) error {
 		return fmt.Errorf("file %s already exists (use --force to overwrite)", outputPath)
 	}
 
+	// Install tags come from the playbook that includes the role
+	playbookTags, err := parser.ParsePlaybookTags(cfg.PlaybookPath(repoType))
+	if err != nil {
+		return fmt.Errorf("parsing playbook: %w", err)
+	}
+
 	// Prepare template data
-	titleCaser := cases.Title(language.English)
 	data := ScaffoldData{
 		RoleName:  roleName,
-		RoleTitle: titleCaser.String(roleName),
-		RoleTag:   roleName,
+		RoleTitle: funcs.Title(roleName),
+		RoleTag:   playbookTags.PrimaryTag(roleName),
+		Tags:      playbookTags.Tags(roleName),
 		RepoType:  repoType,
 		TagPrefix: "",
 	}
 	if repoType == "sandbox" {
-		data.TagPrefix = "sandbox-"
+		data.TagPrefix = parser.SandboxTagPrefix
 	}
 
 	// Load template
@@ -104,7 +111,7 @@ func scaffoldRole(cfg *config.Config, roleName string) error {
 		templatePath = cfg.ScaffoldTemplatePath()
 	}
 
-	tmpl, err := template.ParseFiles(templatePath)
+	tmpl, err := loadScaffoldTemplate(templatePath)
 	if err != nil {
 		return fmt.Errorf("loading template %s: %w", templatePath, err)
 	}
@@ -129,3 +136,20 @@ func scaffoldRole(cfg *config.Config, roleName string) error {
 	fmt.Printf("Created %s\n", outputPath)
 	return nil
 }
+
+// loadScaffoldTemplate parses the scaffold template at path with the shared function library.
+func loadScaffoldTemplate(path string) (*template.Template, error) {
+	return template.New(filepath.Base(path)).Funcs(funcs.Map()).ParseFiles(path)
+}
+
+// sampleScaffoldData returns synthetic ScaffoldData used to smoke render the scaffold template.
+func sampleScaffoldData() ScaffoldData {
+	return ScaffoldData{
+		RoleName:  "sampleapp",
+		RoleTitle: "Sampleapp",
+		RoleTag:   "sampleapp",
+		Tags:      []string{"sampleapp", "sampleapps"},
+		RepoType:  "sandbox",
+		TagPrefix: parser.SandboxTagPrefix,
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/github"
+	"github.com/saltyorg/docs-automation/internal/parser"
+	"github.com/saltyorg/docs-automation/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsHistory   string
+	statsNoHistory bool
+	statsRuns      int
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Record documentation coverage statistics",
+	Long: `Compute the documentation coverage of the Saltbox and Sandbox roles:
+documented and undocumented roles, the share of docs with managed
+variables and overview sections, frontmatter completeness (summary, links
+and categories) and the number of role variables without a comment.
+
+The results are appended to a JSON-lines history file (stats.history_file,
+or --history) and printed with the trend over the last runs. In GitHub
+Actions the report is also written to the step summary. Keep the history
+file between runs, e.g. by committing it or caching it, to see the trend.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if statsRuns < 1 {
+			return fmt.Errorf("--runs must be at least 1, got %d", statsRuns)
+		}
+
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return runStats(cfg)
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsHistory, "history", "", "history file (default: stats.history_file)")
+	statsCmd.Flags().BoolVar(&statsNoHistory, "no-history", false, "show the trend without appending to the history")
+	statsCmd.Flags().IntVar(&statsRuns, "runs", 10, "number of runs shown in the trend")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// runStats computes the coverage, records it in the history and reports it.
+func runStats(cfg *config.Config) error {
+	snapshot := stats.Snapshot{Time: time.Now().UTC(), Repos: map[string]stats.RepoStats{}}
+	for _, repoType := range []string{"saltbox", "sandbox"} {
+		repoStats, err := collectRepoStats(cfg, repoType)
+		if err != nil {
+			return err
+		}
+		snapshot.Repos[repoType] = repoStats
+	}
+
+	historyPath := statsHistory
+	if historyPath == "" {
+		historyPath = cfg.StatsHistoryFile()
+	}
+	history, err := stats.LoadHistory(historyPath)
+	if err != nil {
+		return err
+	}
+	history = append(history, snapshot)
+
+	if !statsNoHistory {
+		if err := stats.AppendHistory(historyPath, snapshot); err != nil {
+			return err
+		}
+		if IsVerbose() {
+			fmt.Fprintf(os.Stderr, "Appended to %s (%d runs)\n", historyPath, len(history))
+		}
+	}
+
+	report := stats.Markdown(history, statsRuns)
+	fmt.Print(report)
+
+	if err := github.AppendStepSummary(report); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write GitHub summary: %v\n", err)
+	}
+
+	return nil
+}
+
+// collectRepoStats computes the coverage of the roles of one repository.
+func collectRepoStats(cfg *config.Config, repoType string) (stats.RepoStats, error) {
+	var result stats.RepoStats
+
+	roles, err := listRoles(rolesPathFor(cfg, repoType))
+	if err != nil {
+		return result, fmt.Errorf("listing %s roles: %w", repoType, err)
+	}
+	blacklist := cfg.Blacklist.DocsCoverage.Saltbox
+	if repoType == "sandbox" {
+		blacklist = cfg.Blacklist.DocsCoverage.Sandbox
+	}
+	roles = filterBlacklist(roles, blacklist)
+
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+
+	for _, role := range roles {
+		result.Roles++
+
+		// Variables are counted for all roles, documented or not
+		defaultsPath := filepath.Join(rolesPathFor(cfg, repoType), role, "defaults", "main.yml")
+		if _, err := os.Stat(defaultsPath); err == nil {
+			roleInfo, err := parser.New(role, repoType).ParseFile(defaultsPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to parse %s: %v\n", defaultsPath, err)
+			} else {
+				for _, v := range parser.FilterVariables(roleInfo.AllVariables, role) {
+					result.Variables++
+					if v.Comment == "" {
+						result.UncommentedVariables++
+					}
+				}
+			}
+		}
+
+		docPath := getDocPath(cfg, role, repoType)
+		if _, err := os.Stat(docPath); err != nil {
+			result.Undocumented++
+			continue
+		}
+		result.Documented++
+
+		doc, err := manager.LoadDocument(docPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to load %s: %v\n", docPath, err)
+			continue
+		}
+		if manager.HasVariablesSection(doc) {
+			result.WithVariables++
+		}
+		if manager.HasOverviewSection(doc) {
+			result.WithOverview++
+		}
+
+		if doc.Frontmatter == nil || doc.Frontmatter.SaltboxAutomation == nil {
+			continue
+		}
+		automation := doc.Frontmatter.SaltboxAutomation
+		if len(automation.AppLinks) > 0 {
+			result.WithLinks++
+		}
+		if description := automation.ProjectDescription; description != nil {
+			if description.Summary != "" {
+				result.WithSummary++
+			}
+			if len(description.Categories) > 0 {
+				result.WithCategories++
+			}
+		}
+	}
+
+	return result, nil
+}
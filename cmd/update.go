@@ -16,10 +16,14 @@ import (
 )
 
 var (
-	updateNoCLI       bool
-	updateRunCheck    bool
-	updateManageIssue bool
-	updateIssueLabel  string
+	updateNoCLI        bool
+	updateRunCheck     bool
+	updateCheckLinks   bool
+	updateManageIssue  bool
+	updateIssueLabel   string
+	updateIssueBackend string
+	updateIssueMode    string
+	updateOpenPR       bool
 )
 
 // skipError represents a non-fatal skip condition (not an actual error).
@@ -51,6 +55,10 @@ With a role argument, updates only that role (no CLI by default).`,
 			role = args[0]
 		}
 
+		if role != "" && updateOpenPR {
+			return fmt.Errorf("--open-pr requires updating all roles")
+		}
+
 		if role != "" {
 			// Update single role
 			return updateRole(cfg, role)
@@ -64,11 +72,27 @@ With a role argument, updates only that role (no CLI by default).`,
 func init() {
 	updateCmd.Flags().BoolVar(&updateNoCLI, "no-cli", false, "exclude CLI help generation")
 	updateCmd.Flags().BoolVar(&updateRunCheck, "check", false, "run coverage checks after updating")
-	updateCmd.Flags().BoolVar(&updateManageIssue, "manage-issue", false, "create/update/close GitHub issue based on check results (requires --check and gh CLI)")
+	updateCmd.Flags().BoolVar(&updateCheckLinks, "check-links", false, "also check external links during coverage checks (requires --check)")
+	updateCmd.Flags().BoolVar(&updateManageIssue, "manage-issue", false, "create/update/close the tracking issue based on check results (requires --check and a forge token or gh CLI)")
 	updateCmd.Flags().StringVar(&updateIssueLabel, "issue-label", "docs-automation", "label to use for the managed GitHub issue")
+	updateCmd.Flags().BoolVar(&updateOpenPR, "open-pr", false, "commit the changed docs to a branch and open or update a pull request (see pull_request in the config)")
+	updateCmd.Flags().StringVar(&updateIssueMode, "issue-mode", "", "issue mode: single (one checklist issue) or per-role (one issue per problem) (default: forge.issue_mode)")
+	updateCmd.Flags().StringVar(&updateIssueBackend, "issue-backend", "", "GitHub issue backend: auto, api or gh (default: github.issue_backend)")
 	rootCmd.AddCommand(updateCmd)
 }
 
+// newForge returns the forge configured under forge (or detected from CI).
+// backend selects the GitHub issue backend.
+func newForge(cfg *config.Config, backend string) (github.Forge, error) {
+	return github.NewForge(github.ForgeOptions{
+		Type:         cfg.Forge.Type,
+		ServerURL:    cfg.Forge.ServerURL,
+		APIURL:       cfg.ForgeAPIURL(),
+		Repo:         cfg.Forge.Repo,
+		IssueBackend: backend,
+	})
+}
+
 // updateRole updates documentation for a single role.
 func updateRole(cfg *config.Config, roleName string) error {
 	// Try to find the role directory in saltbox first, then sandbox.
@@ -85,7 +109,11 @@ func updateRole(cfg *config.Config, roleName string) error {
 		}
 	}
 
-	return updateRoleWithType(cfg, roleName, repoType)
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return err
+	}
+	return updateRoleWithType(cfg, sources, roleName, repoType)
 }
 
 // updateAllRoles updates documentation for all roles.
@@ -111,6 +139,10 @@ func updateAllRoles(cfg *config.Config) error {
 			len(saltboxRoles), len(sandboxRoles))
 	}
 
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return err
+	}
 	summary := github.NewUpdateSummary()
 
 	// Update each role
@@ -118,7 +150,7 @@ func updateAllRoles(cfg *config.Config) error {
 		if IsVerbose() {
 			fmt.Fprintf(os.Stderr, "Updating: %s (saltbox)\n", role)
 		}
-		result := updateRoleWithResult(cfg, role, "saltbox")
+		result := updateRoleWithResult(cfg, sources, role, "saltbox")
 		summary.AddRole(result)
 
 		switch result.Status {
@@ -133,7 +165,7 @@ func updateAllRoles(cfg *config.Config) error {
 		if IsVerbose() {
 			fmt.Fprintf(os.Stderr, "Updating: %s (sandbox)\n", role)
 		}
-		result := updateRoleWithResult(cfg, role, "sandbox")
+		result := updateRoleWithResult(cfg, sources, role, "sandbox")
 		summary.AddRole(result)
 
 		switch result.Status {
@@ -159,6 +191,11 @@ func updateAllRoles(cfg *config.Config) error {
 	// Run coverage checks if requested
 	if updateRunCheck {
 		checkResult, err := runCoverageChecks(cfg)
+		if err == nil && updateCheckLinks {
+			if linkErr := runLinkChecks(cfg, nil, checkResult); linkErr != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to check links: %v\n", linkErr)
+			}
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to run coverage checks: %v\n", err)
 		} else {
@@ -166,17 +203,58 @@ func updateAllRoles(cfg *config.Config) error {
 
 			// Print check results
 			printCoverageCheckResults(checkResult)
+			annotateCheckResult(cfg, checkResult)
 
-			// Manage GitHub issue if requested
+			// Manage the tracking issue if requested
+			var forge github.Forge
 			if updateManageIssue {
-				repo := github.GetRepository()
-				workflowURL := github.GetWorkflowURL()
-				issueManager := github.NewIssueManager(repo, workflowURL)
+				backend := cfg.GitHub.IssueBackend
+				if updateIssueBackend != "" {
+					backend = updateIssueBackend
+				}
+
+				forge, err = newForge(cfg, backend)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to manage tracking issue: %v\n", err)
+				} else {
+					mode := cfg.Forge.IssueMode
+					if updateIssueMode != "" {
+						mode = updateIssueMode
+					}
+
+					issueManager := github.NewIssueManager(forge, github.GetWorkflowURL())
+					switch mode {
+					case "", github.IssueModeSingle:
+						err = issueManager.ManageIssue(checkResult, updateIssueLabel)
+					case github.IssueModePerRole:
+						err = issueManager.ManageItemIssues(checkResult, updateIssueLabel)
+					default:
+						err = fmt.Errorf("unknown issue mode %q (expected single or per-role)", mode)
+					}
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Warning: failed to manage tracking issue: %v\n", err)
+					}
+				}
+			}
 
-				if err := issueManager.ManageIssue(checkResult, updateIssueLabel); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to manage GitHub issue: %v\n", err)
+			// Set the step outputs for later workflow steps. The issue body
+			// only needs the forge for source links, so without
+			// --manage-issue one without an API client will do.
+			if forge == nil {
+				var err error
+				forge, err = github.NewSourceForge(github.ForgeOptions{
+					Type:      cfg.Forge.Type,
+					ServerURL: cfg.Forge.ServerURL,
+					APIURL:    cfg.ForgeAPIURL(),
+					Repo:      cfg.Forge.Repo,
+				})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: source links in the step outputs may be wrong: %v\n", err)
+					env := github.DetectCI()
+					forge = github.NewGitHubForge(nil, env.ServerURL, env.Repo)
 				}
 			}
+			github.NewIssueManager(forge, github.GetWorkflowURL()).OutputGitHubActions(checkResult)
 		}
 	}
 
@@ -185,12 +263,23 @@ func updateAllRoles(cfg *config.Config) error {
 		fmt.Fprintf(os.Stderr, "Warning: failed to write GitHub summary: %v\n", err)
 	}
 
+	// Propose the changes as a pull request if requested
+	if updateOpenPR {
+		backend := cfg.GitHub.IssueBackend
+		if updateIssueBackend != "" {
+			backend = updateIssueBackend
+		}
+		if err := openDocsPullRequest(cfg, summary, backend); err != nil {
+			return fmt.Errorf("opening pull request: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // updateRoleWithType updates documentation for a role with known repo type.
-func updateRoleWithType(cfg *config.Config, roleName, repoType string) error {
-	result := updateRoleWithResult(cfg, roleName, repoType)
+func updateRoleWithType(cfg *config.Config, sources *roleSources, roleName, repoType string) error {
+	result := updateRoleWithResult(cfg, sources, roleName, repoType)
 	if result.Status == github.StatusError {
 		return fmt.Errorf("%s", result.Error)
 	}
@@ -201,7 +290,45 @@ func updateRoleWithType(cfg *config.Config, roleName, repoType string) error {
 }
 
 // updateRoleWithResult updates documentation for a role and returns a detailed result.
-func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.RoleResult {
+func updateRoleWithResult(cfg *config.Config, sources *roleSources, roleName, repoType string) github.RoleResult {
+	// Create docs manager
+	manager := docs.NewManager(docs.MarkerConfig{
+		Variables: cfg.Markers.Variables,
+		CLI:       cfg.Markers.CLI,
+		Overview:  cfg.Markers.Overview,
+	})
+
+	result, doc, originalContent := renderRole(cfg, manager, sources, roleName, repoType)
+	if result.Status != github.StatusUpdated {
+		return result
+	}
+
+	// Check if content actually changed
+	if doc.Content == originalContent {
+		result.Status = github.StatusUnchanged
+		return result
+	}
+	recordRoleChanges(cfg, &result, originalContent, doc.Content)
+
+	// Save the document
+	if err := manager.SaveDocument(doc); err != nil {
+		result.Status = github.StatusError
+		result.Error = fmt.Sprintf("saving document: %v", err)
+		return result
+	}
+
+	if IsVerbose() {
+		fmt.Fprintf(os.Stderr, "  Updated %s\n", doc.Path)
+	}
+
+	return result
+}
+
+// renderRole renders the managed sections of a role's doc in memory without
+// saving it. It returns the document with the rendered content and the
+// content read from disk; the document is only set when result.Status is
+// github.StatusUpdated, meaning at least one section was rendered.
+func renderRole(cfg *config.Config, manager *docs.Manager, sources *roleSources, roleName, repoType string) (github.RoleResult, *docs.Document, string) {
 	result := github.RoleResult{
 		Name:     roleName,
 		RepoType: repoType,
@@ -209,6 +336,14 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 		Sections: []string{},
 	}
 
+	// fail records an error of the role, annotated on the file it was found in
+	fail := func(rule, file string, line int, message string) (github.RoleResult, *docs.Document, string) {
+		result.Status = github.StatusError
+		result.Error = message
+		annotator.Add(github.Annotation{Rule: rule, File: file, Line: line, Message: fmt.Sprintf("%s (%s): %s", roleName, repoType, message)})
+		return result, nil, ""
+	}
+
 	var rolesPath string
 	if repoType == "saltbox" {
 		rolesPath = cfg.SaltboxRolesPath()
@@ -221,31 +356,21 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 	// Get documentation path
 	docPath := getDocPath(cfg, roleName, repoType)
 	if docPath == "" {
-		result.Status = github.StatusError
-		result.Error = "could not determine doc path"
-		return result
+		return fail(github.RuleUpdate, "", 0, "could not determine doc path")
 	}
+	result.Doc = docPath
 
 	// Check if doc file exists
 	if _, err := os.Stat(docPath); os.IsNotExist(err) {
 		result.Status = github.StatusSkipped
 		result.SkipReason = "doc file does not exist"
-		return result
+		return result, nil, ""
 	}
 
-	// Create docs manager
-	manager := docs.NewManager(docs.MarkerConfig{
-		Variables: cfg.Markers.Variables,
-		CLI:       cfg.Markers.CLI,
-		Overview:  cfg.Markers.Overview,
-	})
-
 	// Load existing document
 	doc, err := manager.LoadDocument(docPath)
 	if err != nil {
-		result.Status = github.StatusError
-		result.Error = fmt.Sprintf("loading document: %v", err)
-		return result
+		return fail(github.RuleFrontmatter, docPath, frontmatterErrorLine(err), fmt.Sprintf("loading document: %v", err))
 	}
 
 	// Store original content to detect actual changes
@@ -255,7 +380,7 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 	if manager.IsAutomationDisabled(doc) {
 		result.Status = github.StatusSkipped
 		result.SkipReason = "automation disabled in frontmatter"
-		return result
+		return result, nil, ""
 	}
 
 	// Get frontmatter config
@@ -272,12 +397,9 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 			inventorySkipReason = "no defaults/main.yml"
 		} else {
 			// Parse the role
-			p := parser.New(roleName, repoType)
-			roleInfo, err := p.ParseFile(defaultsPath)
+			roleInfo, err := parseRole(roleName, repoType, defaultsPath)
 			if err != nil {
-				result.Status = github.StatusError
-				result.Error = fmt.Sprintf("parsing: %v", err)
-				return result
+				return fail(github.RuleParse, defaultsPath, github.ErrorLine(err.Error()), fmt.Sprintf("parsing: %v", err))
 			}
 
 			// Skip if no variables (use filtered count for this check)
@@ -286,28 +408,16 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 				inventorySkipReason = "no documentable variables"
 			} else {
 				// Build template data
-				data := template.BuildRoleData(roleInfo, cfg, fmConfig)
-
-				// Create template engine and render
-				engine := template.New()
-				if err := engine.LoadFile("inventory", cfg.InventoryTemplatePath()); err != nil {
-					result.Status = github.StatusError
-					result.Error = fmt.Sprintf("loading template: %v", err)
-					return result
-				}
+				data := template.BuildRoleData(roleInfo, cfg, fmConfig, sources.playbookTags[repoType], sources.inventory)
 
-				output, err := engine.Render("inventory", data)
+				output, err := renderInventory(cfg, data, fmConfig)
 				if err != nil {
-					result.Status = github.StatusError
-					result.Error = fmt.Sprintf("rendering: %v", err)
-					return result
+					return fail(github.RuleUpdate, docPath, 0, err.Error())
 				}
 
 				// Update the managed section
 				if err := manager.UpdateVariablesSection(doc, output); err != nil {
-					result.Status = github.StatusError
-					result.Error = fmt.Sprintf("updating section: %v", err)
-					return result
+					return fail(github.RuleMarkers, docPath, markerErrorLine(doc.Content), fmt.Sprintf("updating section: %v", err))
 				}
 				result.Sections = append(result.Sections, "variables")
 			}
@@ -318,21 +428,15 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 	if fmConfig.IsOverviewSectionEnabled() && manager.HasOverviewSection(doc) {
 		tableGen := overview.NewTableGenerator(cfg.OverviewTemplatePath())
 		if err := tableGen.LoadTemplate(); err != nil {
-			result.Status = github.StatusError
-			result.Error = fmt.Sprintf("loading overview template: %v", err)
-			return result
+			return fail(github.RuleUpdate, docPath, 0, fmt.Sprintf("loading overview template: %v", err))
 		}
 		tableContent, err := tableGen.GenerateFromDocument(doc)
 		if err != nil {
-			result.Status = github.StatusError
-			result.Error = fmt.Sprintf("generating overview table: %v", err)
-			return result
+			return fail(github.RuleUpdate, docPath, 0, fmt.Sprintf("generating overview table: %v", err))
 		}
 		if tableContent != "" {
 			if err := manager.UpdateOverviewSection(doc, tableContent); err != nil {
-				result.Status = github.StatusError
-				result.Error = fmt.Sprintf("updating overview section: %v", err)
-				return result
+				return fail(github.RuleMarkers, docPath, markerErrorLine(doc.Content), fmt.Sprintf("updating overview section: %v", err))
 			}
 			result.Sections = append(result.Sections, "overview")
 		}
@@ -346,27 +450,50 @@ func updateRoleWithResult(cfg *config.Config, roleName, repoType string) github.
 		} else {
 			result.SkipReason = "no enabled sections to update"
 		}
-		return result
+		return result, nil, ""
 	}
 
-	// Check if content actually changed
-	if doc.Content == originalContent {
-		result.Status = github.StatusUnchanged
-		return result
+	return result, doc, originalContent
+}
+
+// recordRoleChanges records in result what rendering changed in the role's
+// doc: the variables added, removed and changed, the number of changed
+// overview fields and a diff of the doc.
+func recordRoleChanges(cfg *config.Config, result *github.RoleResult, original, rendered string) {
+	oldSection := docs.FindManagedSection(original, cfg.Markers.Variables)
+	newSection := docs.FindManagedSection(rendered, cfg.Markers.Variables)
+	if oldSection != nil && newSection != nil {
+		changes := docs.DiffVariables(oldSection.Content, newSection.Content, result.Name)
+		result.Added, result.Removed, result.Changed = changes.Added, changes.Removed, changes.Changed
 	}
 
-	// Save the document
-	if err := manager.SaveDocument(doc); err != nil {
-		result.Status = github.StatusError
-		result.Error = fmt.Sprintf("saving document: %v", err)
-		return result
+	oldOverview := docs.FindManagedSection(original, cfg.Markers.Overview)
+	newOverview := docs.FindManagedSection(rendered, cfg.Markers.Overview)
+	if oldOverview != nil && newOverview != nil {
+		result.OverviewFields = docs.ChangedOverviewFields(oldOverview.Content, newOverview.Content)
 	}
 
-	if IsVerbose() {
-		fmt.Fprintf(os.Stderr, "  Updated %s\n", docPath)
+	doc := relDocsPath(cfg, result.Doc)
+	result.Diff = docs.UnifiedDiff("a/"+doc, "b/"+doc, original, rendered)
+}
+
+// frontmatterErrorLine returns the doc line of a frontmatter YAML error,
+// or 0 when the error has no line.
+func frontmatterErrorLine(err error) int {
+	line := github.ErrorLine(err.Error())
+	if line > 0 {
+		line++ // YAML lines start after the opening ---
 	}
+	return line
+}
 
-	return result
+// markerErrorLine returns the line of the first unmatched managed section
+// marker of a doc, or 0.
+func markerErrorLine(content string) int {
+	if problems := docs.FindMarkerProblems(content); len(problems) > 0 {
+		return problems[0].Line
+	}
+	return 0
 }
 
 // runCoverageChecks performs coverage checks and returns the results.
@@ -445,35 +572,13 @@ func runCoverageChecks(cfg *config.Config) (*github.CheckResult, error) {
 		}
 	}
 
-	// Build set of doc names that are targets of path overrides
-	overrideTargets := make(map[string]bool)
-	for _, repoOverrides := range cfg.PathOverrides {
-		for _, overridePath := range repoOverrides {
-			fullPath := filepath.Join(cfg.Repositories.Docs, overridePath)
-			if _, err := os.Stat(fullPath); err == nil {
-				baseName := strings.TrimSuffix(filepath.Base(overridePath), ".md")
-				overrideTargets[baseName] = true
-			}
-		}
-	}
-
 	// Check for orphaned documentation
-	for name := range saltboxDocMap {
-		if saltboxBlacklist[name] || overrideTargets[name] {
-			continue
-		}
-		if !saltboxRoleSet[name] {
-			result.OrphanedDocs = append(result.OrphanedDocs, name)
-		}
+	orphans, err := listOrphanedDocs(cfg)
+	if err != nil {
+		return nil, err
 	}
-
-	for name := range sandboxDocMap {
-		if sandboxBlacklist[name] || overrideTargets[name] {
-			continue
-		}
-		if !sandboxRoleSet[name] {
-			result.OrphanedDocs = append(result.OrphanedDocs, "sandbox/"+name)
-		}
+	for _, orphan := range orphans {
+		result.OrphanedDocs = append(result.OrphanedDocs, orphan.Name)
 	}
 
 	// Check for missing managed sections
@@ -484,6 +589,13 @@ func runCoverageChecks(cfg *config.Config) (*github.CheckResult, error) {
 	})
 	checkedDocs := make(map[string]bool)
 
+	// Load playbook tags for install command checks
+	sources, err := loadRoleSources(cfg)
+	if err != nil {
+		return nil, err
+	}
+	saltboxTags, sandboxTags := sources.playbookTags["saltbox"], sources.playbookTags["sandbox"]
+
 	// Check saltbox docs
 	for _, docPath := range saltboxDocs {
 		checkedDocs[docPath] = true
@@ -500,6 +612,7 @@ func runCoverageChecks(cfg *config.Config) (*github.CheckResult, error) {
 			hasDefaults = false
 		}
 		checkDocManagedSections(manager, docPath, cfg.Repositories.Docs, result, hasDefaults)
+		checkDocInstallTags(docPath, cfg.Repositories.Docs, saltboxTags, sandboxTags, result)
 	}
 
 	// Check sandbox docs
@@ -518,11 +631,47 @@ func runCoverageChecks(cfg *config.Config) (*github.CheckResult, error) {
 			hasDefaults = false
 		}
 		checkDocManagedSections(manager, docPath, cfg.Repositories.Docs, result, hasDefaults)
+		checkDocInstallTags(docPath, cfg.Repositories.Docs, saltboxTags, sandboxTags, result)
 	}
 
 	return result, nil
 }
 
+// annotateCheckResult reports the problems found by the coverage checks as
+// annotations. Roles without docs are annotated on their defaults or tasks.
+func annotateCheckResult(cfg *config.Config, result *github.CheckResult) {
+	orphanPaths := make(map[string]string)
+	if len(result.OrphanedDocs) > 0 {
+		orphans, err := listOrphanedDocs(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to locate orphaned docs: %v\n", err)
+		}
+		for _, orphan := range orphans {
+			orphanPaths[orphan.Name] = orphan.Path
+		}
+	}
+
+	locate := func(kind, name string) string {
+		if kind == github.KindOrphanedDocs {
+			return orphanPaths[name]
+		}
+		rolePath := filepath.Join(cfg.SaltboxRolesPath(), name)
+		if role, ok := strings.CutPrefix(name, "sandbox/"); ok {
+			rolePath = filepath.Join(cfg.SandboxRolesPath(), role)
+		}
+		for _, file := range []string{"defaults/main.yml", "tasks/main.yml"} {
+			if _, err := os.Stat(filepath.Join(rolePath, file)); err == nil {
+				return filepath.Join(rolePath, file)
+			}
+		}
+		return rolePath
+	}
+
+	for _, annotation := range github.CheckAnnotations(result, cfg.Repositories.Docs, locate) {
+		annotator.Add(annotation)
+	}
+}
+
 // roleHasDocCheck checks if a role has documentation.
 func roleHasDocCheck(cfg *config.Config, roleName, repoType string, docMap map[string]string) bool {
 	if repoOverrides, ok := cfg.PathOverrides[repoType]; ok {
@@ -564,6 +713,30 @@ func checkDocManagedSections(manager *docs.Manager, docPath, docsRoot string, re
 	}
 }
 
+// checkDocInstallTags checks that the tags of "sb install" commands in a doc exist in the playbooks.
+// Tags with the sandbox- prefix are checked against sandbox.yml, others against saltbox.yml.
+// A playbook without roles (e.g. missing) is not checked.
+func checkDocInstallTags(docPath, docsRoot string, saltboxTags, sandboxTags parser.PlaybookTags, result *github.CheckResult) {
+	content, err := os.ReadFile(docPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to read %s: %v\n", docPath, err)
+		return
+	}
+
+	relPath, _ := filepath.Rel(docsRoot, docPath)
+
+	for _, ref := range parser.ExtractInstallTags(string(content)) {
+		tags, tag := saltboxTags, ref.Tag
+		if strings.HasPrefix(tag, parser.SandboxTagPrefix) {
+			tags, tag = sandboxTags, strings.TrimPrefix(tag, parser.SandboxTagPrefix)
+		}
+		if len(tags) == 0 || tags.HasTag(tag) {
+			continue
+		}
+		result.InvalidInstallTags = append(result.InvalidInstallTags, fmt.Sprintf("%s:%d: %s", relPath, ref.Line, ref.Tag))
+	}
+}
+
 // printCoverageCheckResults prints the coverage check results.
 func printCoverageCheckResults(result *github.CheckResult) {
 	fmt.Println()
@@ -586,6 +759,14 @@ func printCoverageCheckResults(result *github.CheckResult) {
 		fmt.Printf("Orphaned Documentation: %d docs\n", len(result.OrphanedDocs))
 	}
 
+	if len(result.InvalidInstallTags) > 0 {
+		fmt.Printf("Invalid Install Tags: %d references\n", len(result.InvalidInstallTags))
+	}
+
+	if len(result.BrokenLinks) > 0 {
+		fmt.Printf("Broken Links: %d links\n", len(result.BrokenLinks))
+	}
+
 	total := result.TotalIssues()
 	if total == 0 {
 		fmt.Println("✅ All coverage checks passed!")
@@ -3,9 +3,16 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/saltyorg/docs-automation/internal/cli"
 	"github.com/saltyorg/docs-automation/internal/config"
 	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/funcs"
+	"github.com/saltyorg/docs-automation/internal/github"
+	"github.com/saltyorg/docs-automation/internal/overview"
+	"github.com/saltyorg/docs-automation/internal/parser"
+	"github.com/saltyorg/docs-automation/internal/template"
 	"github.com/spf13/cobra"
 )
 
@@ -18,14 +25,27 @@ var validateCmd = &cobra.Command{
 var validateConfigCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Validate config.yml",
-	Long:  "Validate the configuration file for required fields and correct format.",
+	Long: `Validate the configuration file for required fields and correct format.
+
+Type inference rules that are shadowed by an earlier rule, or whose
+precedence depends only on their order in the config, are reported as
+warnings.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Load() now calls Validate() automatically
-		_, err := config.Load(GetConfigPath())
+		cfg, err := config.Load(GetConfigPath())
 		if err != nil {
 			return err
 		}
 
+		issues := parser.CheckTypeRules(&cfg.TypeInference)
+		for _, issue := range issues {
+			fmt.Printf("⚠️  type_inference.%s\n", issue)
+		}
+		if len(issues) > 0 {
+			fmt.Printf("✅ Config is valid (%d type inference warnings)\n", len(issues))
+			return nil
+		}
+
 		fmt.Println("✅ Config is valid")
 		return nil
 	},
@@ -34,7 +54,11 @@ var validateConfigCmd = &cobra.Command{
 var validateFrontmatterCmd = &cobra.Command{
 	Use:   "frontmatter",
 	Short: "Validate frontmatter in doc files",
-	Long:  "Validate frontmatter configuration in documentation files.",
+	Long: `Validate frontmatter configuration in documentation files, and check
+that every managed section BEGIN marker has a matching END marker.
+
+In GitHub Actions each problem is also reported as an error annotation on
+its file and line.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load(GetConfigPath())
 		if err != nil {
@@ -45,9 +69,30 @@ var validateFrontmatterCmd = &cobra.Command{
 	},
 }
 
+var validateTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Validate documentation templates",
+	Long: `Validate documentation templates against the data they are rendered with.
+
+Each configured template (inventory and its variants, overview, CLI help and
+scaffold) is parsed with its function map. Field references are checked
+against RoleData, TableData, HelpData and ScaffoldData, and the template is
+smoke rendered with synthetic fixture data. Problems are reported with the
+template line and column.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load(GetConfigPath())
+		if err != nil {
+			return fmt.Errorf("loading config: %w", err)
+		}
+
+		return validateTemplates(cfg)
+	},
+}
+
 func init() {
 	validateCmd.AddCommand(validateConfigCmd)
 	validateCmd.AddCommand(validateFrontmatterCmd)
+	validateCmd.AddCommand(validateTemplatesCmd)
 	rootCmd.AddCommand(validateCmd)
 }
 
@@ -96,6 +141,16 @@ func validateFrontmatter(cfg *config.Config) error {
 		fm, _, err := docs.ParseFrontmatter(string(content))
 		if err != nil {
 			fmt.Printf("❌ %s: %v\n", docPath, err)
+			annotator.Add(github.Annotation{Rule: github.RuleFrontmatter, File: docPath, Line: frontmatterErrorLine(err), Message: err.Error()})
+			invalid++
+			continue
+		}
+
+		if problems := docs.FindMarkerProblems(string(content)); len(problems) > 0 {
+			for _, problem := range problems {
+				fmt.Printf("❌ %s:%d: %s\n", docPath, problem.Line, problem.Message)
+				annotator.Add(github.Annotation{Rule: github.RuleMarkers, File: docPath, Line: problem.Line, Message: problem.Message})
+			}
 			invalid++
 			continue
 		}
@@ -112,9 +167,21 @@ func validateFrontmatter(cfg *config.Config) error {
 		if fm.SaltboxAutomation != nil {
 			if err := validateSaltboxAutomation(fm.SaltboxAutomation); err != nil {
 				fmt.Printf("❌ %s: %v\n", docPath, err)
+				annotator.Add(github.Annotation{Rule: github.RuleFrontmatter, File: docPath, Line: 1, Message: err.Error()})
 				invalid++
 				continue
 			}
+
+			if variant := fm.SaltboxAutomation.InventoryTemplate(); variant != "" {
+				variantPath := filepath.Join(cfg.InventoryVariantsPath(), variant+".md.tmpl")
+				if _, err := os.Stat(variantPath); err != nil {
+					fmt.Printf("❌ %s: inventory.template: %q not found at %s\n", docPath, variant, variantPath)
+					annotator.Add(github.Annotation{Rule: github.RuleFrontmatter, File: docPath, Line: 1,
+						Message: fmt.Sprintf("inventory.template: %q not found at %s", variant, variantPath)})
+					invalid++
+					continue
+				}
+			}
 		}
 
 		valid++
@@ -154,3 +221,90 @@ func validateSaltboxAutomation(sa *docs.SaltboxAutomationConfig) error {
 
 	return nil
 }
+
+// validateTemplates lints all configured templates.
+func validateTemplates(cfg *config.Config) error {
+	failed := 0
+
+	// report prints the lint result for a single template.
+	report := func(name string, issues []template.LintIssue) {
+		if len(issues) == 0 {
+			fmt.Printf("✅ %s\n", name)
+			return
+		}
+		failed++
+		fmt.Printf("❌ %s\n", name)
+		for _, issue := range issues {
+			fmt.Printf("   %s\n", issue)
+		}
+	}
+
+	// loadFailed prints a template that could not be loaded.
+	loadFailed := func(name string, err error) {
+		failed++
+		fmt.Printf("❌ %s: %v\n", name, err)
+	}
+
+	// Inventory base template and variants
+	engine, err := loadInventoryEngine(cfg)
+	if err != nil {
+		loadFailed("inventory", err)
+	} else {
+		for _, name := range engine.Names() {
+			report(name, template.Lint(engine.Lookup(name), template.FuncMap(), template.SampleRoleData()))
+		}
+	}
+
+	// Overview template
+	if templateExists(cfg.OverviewTemplatePath()) {
+		tableGen := overview.NewTableGenerator(cfg.OverviewTemplatePath())
+		if err := tableGen.LoadTemplate(); err != nil {
+			loadFailed("overview", err)
+		} else {
+			sample := template.SampleRoleData().Config
+			data := overview.TableData{
+				Description: sample.ProjectDescription,
+				Links:       sample.AppLinks,
+			}
+			report("overview", template.Lint(tableGen.Template(), funcs.Map(), data))
+		}
+	}
+
+	// CLI help template
+	if templateExists(cfg.CLIHelpTemplatePath()) {
+		generator := cli.NewHelpGenerator("", cfg.CLIHelpTemplatePath())
+		if err := generator.LoadTemplate(); err != nil {
+			loadFailed("cli_help", err)
+		} else {
+			data := cli.HelpData{HelpText: "Usage:\n  sb [command]"}
+			report("cli_help", template.Lint(generator.Template(), funcs.Map(), data))
+		}
+	}
+
+	// Scaffold template
+	if templateExists(cfg.ScaffoldTemplatePath()) {
+		tmpl, err := loadScaffoldTemplate(cfg.ScaffoldTemplatePath())
+		if err != nil {
+			loadFailed("scaffold", err)
+		} else {
+			report("scaffold", template.Lint(tmpl, funcs.Map(), sampleScaffoldData()))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("found %d invalid templates", failed)
+	}
+
+	return nil
+}
+
+// templateExists reports whether an optional template file is present.
+func templateExists(path string) bool {
+	if _, err := os.Stat(path); err != nil {
+		if IsVerbose() {
+			fmt.Printf("⚠️  %s: not found, skipping\n", path)
+		}
+		return false
+	}
+	return true
+}
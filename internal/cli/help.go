@@ -7,6 +7,8 @@ import (
 	"os/exec"
 	"strings"
 	"text/template"
+
+	"github.com/saltyorg/docs-automation/internal/funcs"
 )
 
 // HelpGenerator generates CLI help documentation.
@@ -40,7 +42,7 @@ func (g *HelpGenerator) LoadTemplate() error {
 		return fmt.Errorf("reading template: %w", err)
 	}
 
-	tmpl, err := template.New("cli_help").Parse(string(content))
+	tmpl, err := template.New("cli_help").Funcs(funcs.Map()).Parse(string(content))
 	if err != nil {
 		return fmt.Errorf("parsing template: %w", err)
 	}
@@ -49,6 +51,11 @@ func (g *HelpGenerator) LoadTemplate() error {
 	return nil
 }
 
+// Template returns the loaded template, or nil if LoadTemplate has not been called.
+func (g *HelpGenerator) Template() *template.Template {
+	return g.tmpl
+}
+
 // Generate executes the binary with -h flag and formats the output using the template.
 func (g *HelpGenerator) Generate() (string, error) {
 	if g.tmpl == nil {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -20,6 +22,13 @@ type Config struct {
 	CLIHelp         CLIHelpConfig                `yaml:"cli_help"`
 	Markers         MarkersConfig                `yaml:"markers"`
 	Scaffold        ScaffoldConfig               `yaml:"scaffold"`
+	LinkCheck       LinkCheckConfig              `yaml:"link_check"`
+	Nav             NavConfig                    `yaml:"nav"`
+	Orphans         OrphansConfig                `yaml:"orphans"`
+	GitHub          GitHubConfig                 `yaml:"github"`
+	Forge           ForgeConfig                  `yaml:"forge"`
+	PullRequest     PullRequestConfig            `yaml:"pull_request"`
+	Stats           StatsConfig                  `yaml:"stats"`
 }
 
 // RepositoryConfig defines paths to the repositories.
@@ -67,9 +76,21 @@ type TypeInferenceConfig struct {
 }
 
 // TypePattern defines a pattern-based type inference rule.
+// Exactly one of SuffixContains or Regex must be set. Rules with a higher
+// Priority are checked first; rules with equal priority keep config order.
 type TypePattern struct {
 	SuffixContains string `yaml:"suffix_contains"`
+	Regex          string `yaml:"regex"`
 	Type           string `yaml:"type"`
+	Priority       int    `yaml:"priority"`
+}
+
+// Describe returns the matcher of the rule for display, e.g. `contains "_port"`.
+func (p TypePattern) Describe() string {
+	if p.Regex != "" {
+		return fmt.Sprintf("regex %q", p.Regex)
+	}
+	return fmt.Sprintf("contains %q", p.SuffixContains)
 }
 
 // DockerVariables categorizes docker container module variables.
@@ -98,6 +119,77 @@ type ScaffoldConfig struct {
 	OutputPaths map[string]string `yaml:"output_paths"`
 }
 
+// LinkCheckConfig configures external link checking.
+// Durations use Go syntax, e.g. "500ms" or "24h".
+type LinkCheckConfig struct {
+	CacheFile   string   `yaml:"cache_file"`  // Result cache, relative to the working directory
+	Concurrency int      `yaml:"concurrency"` // Parallel requests
+	RateLimit   string   `yaml:"rate_limit"`  // Minimum delay between requests to the same host
+	Retries     int      `yaml:"retries"`     // Retries for network errors, 429 and 5xx
+	Timeout     string   `yaml:"timeout"`     // Per-request timeout
+	MaxAge      string   `yaml:"max_age"`     // Reuse cached results younger than this (default 24h)
+	Ignore      []string `yaml:"ignore"`      // URL prefixes that are never checked
+}
+
+// Duration parses one of the link check durations, returning def when unset.
+func (c LinkCheckConfig) Duration(value string, def time.Duration) time.Duration {
+	if value == "" {
+		return def
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return def
+	}
+	return d
+}
+
+// NavConfig configures the generated MkDocs nav.
+type NavConfig struct {
+	File    string   `yaml:"file"`     // MkDocs config, relative to the docs repo (default "mkdocs.yml")
+	Saltbox []string `yaml:"saltbox"`  // Nav section titles leading to the saltbox apps (default ["Apps"])
+	Sandbox []string `yaml:"sandbox"`  // Nav section titles leading to the sandbox apps (default ["Sandbox", "Apps"])
+	GroupBy string   `yaml:"group_by"` // "alpha" (default) or "category"
+}
+
+// OrphansConfig configures the archiving of docs whose role was removed.
+type OrphansConfig struct {
+	ArchiveDir string `yaml:"archive_dir"` // Archive folder, relative to the docs repo (default "docs/archive")
+	Notice     string `yaml:"notice"`      // Deprecation admonition text
+	PlanFile   string `yaml:"plan_file"`   // Reviewable plan, relative to the working directory (default "orphans-plan.yml")
+}
+
+// GitHubConfig configures GitHub-specific issue management.
+type GitHubConfig struct {
+	IssueBackend string `yaml:"issue_backend"` // "auto" (default), "api" or "gh"
+
+	// Deprecated: APIURL is read as forge.api_url when that is not set.
+	APIURL string `yaml:"api_url"`
+}
+
+// ForgeConfig selects the forge hosting the tracking issue. Empty fields
+// are detected from the CI environment.
+type ForgeConfig struct {
+	Type      string `yaml:"type"`       // "auto" (default), "github", "gitlab" or "gitea"
+	ServerURL string `yaml:"server_url"` // Web URL, e.g. https://gitea.example.com
+	APIURL    string `yaml:"api_url"`    // API base URL (default derived from server_url)
+	Repo      string `yaml:"repo"`       // "owner/repo", or the GitLab project path
+	IssueMode string `yaml:"issue_mode"` // "single" (default) or "per-role"
+}
+
+// PullRequestConfig configures the rolling pull request opened by
+// update --open-pr.
+type PullRequestConfig struct {
+	Branch string `yaml:"branch"` // Head branch (default "sb-docs/update")
+	Base   string `yaml:"base"`   // Base branch (default the checked out branch)
+	Remote string `yaml:"remote"` // Remote to push to (default "origin")
+	Author string `yaml:"author"` // Commit author "Name <email>" (default the git identity)
+}
+
+// StatsConfig configures the coverage statistics recorded by stats.
+type StatsConfig struct {
+	HistoryFile string `yaml:"history_file"` // JSON-lines history, relative to the working directory (default "stats-history.jsonl")
+}
+
 // Load reads and parses a config file from the given path.
 func Load(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
@@ -133,6 +225,66 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("markers.variables is required")
 	}
 
+	// Validate type inference pattern rules
+	for i, pattern := range c.TypeInference.Patterns {
+		if (pattern.SuffixContains == "") == (pattern.Regex == "") {
+			return fmt.Errorf("type_inference.patterns[%d]: exactly one of suffix_contains or regex is required", i)
+		}
+		if pattern.Type == "" {
+			return fmt.Errorf("type_inference.patterns[%d]: type is required", i)
+		}
+		if pattern.Regex != "" {
+			if _, err := regexp.Compile(pattern.Regex); err != nil {
+				return fmt.Errorf("type_inference.patterns[%d]: invalid regex: %w", i, err)
+			}
+		}
+	}
+
+	// Validate nav grouping
+	switch c.Nav.GroupBy {
+	case "", "alpha", "category":
+	default:
+		return fmt.Errorf("nav.group_by: unknown mode %q (expected alpha or category)", c.Nav.GroupBy)
+	}
+
+	// Validate issue backend
+	switch c.GitHub.IssueBackend {
+	case "", "auto", "api", "gh":
+	default:
+		return fmt.Errorf("github.issue_backend: unknown backend %q (expected auto, api or gh)", c.GitHub.IssueBackend)
+	}
+
+	// github.api_url is a deprecated alias of forge.api_url
+	if c.GitHub.APIURL != "" && c.Forge.APIURL != "" && c.GitHub.APIURL != c.Forge.APIURL {
+		return fmt.Errorf("github.api_url is deprecated and conflicts with forge.api_url; remove github.api_url")
+	}
+
+	// Validate forge type
+	switch c.Forge.Type {
+	case "", "auto", "github", "gitlab", "gitea":
+	default:
+		return fmt.Errorf("forge.type: unknown forge %q (expected auto, github, gitlab or gitea)", c.Forge.Type)
+	}
+	switch c.Forge.IssueMode {
+	case "", "single", "per-role":
+	default:
+		return fmt.Errorf("forge.issue_mode: unknown mode %q (expected single or per-role)", c.Forge.IssueMode)
+	}
+
+	// Validate link check durations
+	for name, value := range map[string]string{
+		"rate_limit": c.LinkCheck.RateLimit,
+		"timeout":    c.LinkCheck.Timeout,
+		"max_age":    c.LinkCheck.MaxAge,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("link_check.%s: %w", name, err)
+		}
+	}
+
 	// Validate repository directories exist
 	if err := validateDirectory(c.Repositories.Saltbox, "repositories.saltbox"); err != nil {
 		return err
@@ -185,6 +337,15 @@ func (c *Config) SandboxRolesPath() string {
 	return filepath.Join(c.Repositories.Sandbox, "roles")
 }
 
+// PlaybookPath returns the path to the playbook that includes the roles of a repo type
+// (saltbox.yml or sandbox.yml).
+func (c *Config) PlaybookPath(repoType string) string {
+	if repoType == "sandbox" {
+		return filepath.Join(c.Repositories.Sandbox, "sandbox.yml")
+	}
+	return filepath.Join(c.Repositories.Saltbox, "saltbox.yml")
+}
+
 // SaltboxDocsPath returns the path to saltbox app docs.
 func (c *Config) SaltboxDocsPath() string {
 	return filepath.Join(c.Repositories.Docs, "docs", "apps")
@@ -195,22 +356,102 @@ func (c *Config) SandboxDocsPath() string {
 	return filepath.Join(c.Repositories.Docs, "docs", "sandbox", "apps")
 }
 
+// TemplatesPath returns the path to the docs templates directory.
+func (c *Config) TemplatesPath() string {
+	return filepath.Join(c.Repositories.Docs, "templates")
+}
+
+// InventoryVariantsPath returns the path to per-document inventory template variants.
+func (c *Config) InventoryVariantsPath() string {
+	return filepath.Join(c.TemplatesPath(), "inventory")
+}
+
+// TemplatePartialsPath returns the path to shared template partials.
+func (c *Config) TemplatePartialsPath() string {
+	return filepath.Join(c.TemplatesPath(), "partials")
+}
+
 // InventoryTemplatePath returns the path to the inventory template.
 func (c *Config) InventoryTemplatePath() string {
-	return filepath.Join(c.Repositories.Docs, "templates", "inventory.md.tmpl")
+	return filepath.Join(c.TemplatesPath(), "inventory.md.tmpl")
 }
 
 // OverviewTemplatePath returns the path to the overview template.
 func (c *Config) OverviewTemplatePath() string {
-	return filepath.Join(c.Repositories.Docs, "templates", "overview.md.tmpl")
+	return filepath.Join(c.TemplatesPath(), "overview.md.tmpl")
 }
 
 // CLIHelpTemplatePath returns the path to the CLI help template.
 func (c *Config) CLIHelpTemplatePath() string {
-	return filepath.Join(c.Repositories.Docs, "templates", "cli_help.md.tmpl")
+	return filepath.Join(c.TemplatesPath(), "cli_help.md.tmpl")
+}
+
+// MkDocsConfigPath returns the full path to the MkDocs config of the docs repo.
+func (c *Config) MkDocsConfigPath() string {
+	file := c.Nav.File
+	if file == "" {
+		file = "mkdocs.yml"
+	}
+	return filepath.Join(c.Repositories.Docs, file)
+}
+
+// NavPath returns the nav section titles leading to the apps of a repo type.
+func (c *Config) NavPath(repoType string) []string {
+	if repoType == "sandbox" {
+		if len(c.Nav.Sandbox) > 0 {
+			return c.Nav.Sandbox
+		}
+		return []string{"Sandbox", "Apps"}
+	}
+	if len(c.Nav.Saltbox) > 0 {
+		return c.Nav.Saltbox
+	}
+	return []string{"Apps"}
+}
+
+// OrphanArchivePath returns the full path to the archive folder of orphaned docs.
+func (c *Config) OrphanArchivePath() string {
+	dir := c.Orphans.ArchiveDir
+	if dir == "" {
+		dir = filepath.Join("docs", "archive")
+	}
+	return filepath.Join(c.Repositories.Docs, dir)
+}
+
+// PullRequestBranch returns the head branch of the update pull request.
+func (c *Config) PullRequestBranch() string {
+	if c.PullRequest.Branch == "" {
+		return "sb-docs/update"
+	}
+	return c.PullRequest.Branch
+}
+
+// PullRequestRemote returns the remote the update branch is pushed to.
+func (c *Config) PullRequestRemote() string {
+	if c.PullRequest.Remote == "" {
+		return "origin"
+	}
+	return c.PullRequest.Remote
 }
 
 // ScaffoldTemplatePath returns the path to the scaffold template.
 func (c *Config) ScaffoldTemplatePath() string {
-	return filepath.Join(c.Repositories.Docs, "templates", "app_scaffold.md.tmpl")
+	return filepath.Join(c.TemplatesPath(), "app_scaffold.md.tmpl")
+}
+
+// ForgeAPIURL returns the configured forge API base URL, falling back to the
+// deprecated github.api_url.
+func (c *Config) ForgeAPIURL() string {
+	if c.Forge.APIURL == "" {
+		return c.GitHub.APIURL
+	}
+	return c.Forge.APIURL
+}
+
+// StatsHistoryFile returns the path of the coverage statistics history.
+func (c *Config) StatsHistoryFile() string {
+	if c.Stats.HistoryFile == "" {
+		return "stats-history.jsonl"
+	}
+	return c.Stats.HistoryFile
 }
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes a config with the required fields followed by extra.
+func writeConfig(t *testing.T, extra string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "roles"), 0o755); err != nil {
+		t.Fatalf("creating roles: %v", err)
+	}
+	path := filepath.Join(dir, "config.yml")
+	content := "repositories:\n  saltbox: " + dir + "\n  sandbox: " + dir + "\n  docs: " + dir +
+		"\nmarkers:\n  variables: variables\n" + extra
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
+
+func TestLoad_DeprecatedGitHubAPIURL(t *testing.T) {
+	cfg, err := Load(writeConfig(t, "github:\n  api_url: https://ghes.example.com/api/v3\n"))
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got := cfg.ForgeAPIURL(); got != "https://ghes.example.com/api/v3" {
+		t.Errorf("ForgeAPIURL = %q, want the github.api_url alias", got)
+	}
+
+	cfg, err = Load(writeConfig(t, "github:\n  api_url: https://old.example.com\nforge:\n  api_url: https://old.example.com\n"))
+	if err != nil {
+		t.Fatalf("Load with matching URLs returned error: %v", err)
+	}
+	if got := cfg.ForgeAPIURL(); got != "https://old.example.com" {
+		t.Errorf("ForgeAPIURL = %q", got)
+	}
+
+	_, err = Load(writeConfig(t, "github:\n  api_url: https://old.example.com\nforge:\n  api_url: https://new.example.com\n"))
+	if err == nil || !strings.Contains(err.Error(), "forge.api_url") {
+		t.Errorf("expected conflicting URLs to point to forge.api_url, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"gopkg.in/yaml.v3"
+)
+
+// RenameRole rewrites the config file text for a role renamed from oldRole
+// to newRole in a repo type: its docs_coverage blacklist entry, its
+// path_overrides key (and the file name of the override when it is named
+// after the role) and type_inference exact/overrides keys that are full
+// variable names of the role. Only the affected top-level entries are
+// re-encoded. It returns the updated text and a description of each change.
+func RenameRole(text, repoType, oldRole, newRole string) (string, []string, error) {
+	var changes []string
+
+	text, _, err := docs.EditTopLevelKey(text, "blacklist", yaml.MappingNode, func(root *yaml.Node) (bool, error) {
+		list := docs.MappingGet(docs.MappingGet(root, "docs_coverage"), repoType)
+		if list == nil || list.Kind != yaml.SequenceNode {
+			return false, nil
+		}
+		changed := false
+		for _, item := range list.Content {
+			if item.Value == oldRole {
+				item.Value = newRole
+				changes = append(changes, fmt.Sprintf("blacklist.docs_coverage.%s: %s → %s", repoType, oldRole, newRole))
+				changed = true
+			}
+		}
+		return changed, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	text, _, err = docs.EditTopLevelKey(text, "path_overrides", yaml.MappingNode, func(root *yaml.Node) (bool, error) {
+		overrides := docs.MappingGet(root, repoType)
+		if overrides == nil || overrides.Kind != yaml.MappingNode {
+			return false, nil
+		}
+		for i := 0; i+1 < len(overrides.Content); i += 2 {
+			key, value := overrides.Content[i], overrides.Content[i+1]
+			if key.Value != oldRole {
+				continue
+			}
+			key.Value = newRole
+			changes = append(changes, fmt.Sprintf("path_overrides.%s: %s → %s", repoType, oldRole, newRole))
+			if renamed := RenamedOverride(value.Value, oldRole, newRole); renamed != value.Value {
+				changes = append(changes, fmt.Sprintf("path_overrides.%s.%s: %s → %s", repoType, newRole, value.Value, renamed))
+				value.Value = renamed
+			}
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	text, _, err = docs.EditTopLevelKey(text, "type_inference", yaml.MappingNode, func(root *yaml.Node) (bool, error) {
+		changed := false
+		for _, field := range []string{"exact", "overrides"} {
+			rules := docs.MappingGet(root, field)
+			if rules == nil || rules.Kind != yaml.MappingNode {
+				continue
+			}
+			for i := 0; i+1 < len(rules.Content); i += 2 {
+				key := rules.Content[i]
+				if !strings.HasPrefix(key.Value, oldRole+"_") {
+					continue
+				}
+				renamed := newRole + "_" + strings.TrimPrefix(key.Value, oldRole+"_")
+				changes = append(changes, fmt.Sprintf("type_inference.%s: %s → %s", field, key.Value, renamed))
+				key.Value = renamed
+				changed = true
+			}
+		}
+		return changed, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return text, changes, nil
+}
+
+// RenamedOverride returns the path_overrides path of a renamed role: the
+// file name is replaced when it is "<oldRole>.md", otherwise (e.g. an
+// index.md in a role folder) the path is kept.
+func RenamedOverride(override, oldRole, newRole string) string {
+	if path.Base(override) != oldRole+".md" {
+		return override
+	}
+	return path.Join(path.Dir(override), newRole+".md")
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenameRole(t *testing.T) {
+	text := `repositories:
+  docs: /srv/docs   # checkout
+blacklist:
+  docs_coverage:
+    saltbox:
+      - organizr # legacy
+      - plex
+    sandbox:
+      - organizr
+path_overrides:
+  saltbox:
+    organizr: docs/apps/home/organizr.md
+    plex: docs/apps/plex/index.md
+type_inference:
+  exact:
+    _port: string
+    organizr_role_web_port: int
+`
+	expected := `repositories:
+  docs: /srv/docs   # checkout
+blacklist:
+  docs_coverage:
+    saltbox:
+      - organizr2 # legacy
+      - plex
+    sandbox:
+      - organizr
+path_overrides:
+  saltbox:
+    organizr2: docs/apps/home/organizr2.md
+    plex: docs/apps/plex/index.md
+type_inference:
+  exact:
+    _port: string
+    organizr2_role_web_port: int
+`
+	updated, changes, err := RenameRole(text, "saltbox", "organizr", "organizr2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated != expected {
+		t.Errorf("RenameRole =\n%s\nwant\n%s", updated, expected)
+	}
+	expectedChanges := []string{
+		"blacklist.docs_coverage.saltbox: organizr → organizr2",
+		"path_overrides.saltbox: organizr → organizr2",
+		"path_overrides.saltbox.organizr2: docs/apps/home/organizr.md → docs/apps/home/organizr2.md",
+		"type_inference.exact: organizr_role_web_port → organizr2_role_web_port",
+	}
+	if !reflect.DeepEqual(changes, expectedChanges) {
+		t.Errorf("changes = %q, want %q", changes, expectedChanges)
+	}
+}
+
+func TestRenamedOverride(t *testing.T) {
+	tests := map[string]string{
+		"docs/apps/organizr.md":       "docs/apps/organizr2.md",
+		"docs/apps/organizr/index.md": "docs/apps/organizr/index.md",
+	}
+	for override, expected := range tests {
+		if got := RenamedOverride(override, "organizr", "organizr2"); got != expected {
+			t.Errorf("RenamedOverride(%q) = %q, want %q", override, got, expected)
+		}
+	}
+}
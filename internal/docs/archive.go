@@ -0,0 +1,117 @@
+package docs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deprecationTitle is the title of the admonition added to archived docs.
+const deprecationTitle = `!!! warning "Deprecated"`
+
+// AddDeprecationNotice adds a deprecation admonition with notice to a
+// document, right below its first level 1 heading (or at the top of the
+// body when there is none). It reports whether the content changed; a
+// document that already has the admonition is left untouched.
+func AddDeprecationNotice(content, notice string) (string, bool) {
+	if strings.Contains(content, deprecationTitle) {
+		return content, false
+	}
+
+	var admonition strings.Builder
+	admonition.WriteString(deprecationTitle + "\n\n")
+	for _, line := range strings.Split(strings.TrimSpace(notice), "\n") {
+		if line == "" {
+			admonition.WriteString("\n")
+			continue
+		}
+		admonition.WriteString("    " + line + "\n")
+	}
+	admonition.WriteString("\n")
+
+	// Insert below the H1 of the body
+	bodyStart := 0
+	if strings.HasPrefix(content, "---") {
+		if end := strings.Index(content[3:], "\n---"); end != -1 {
+			bodyStart = 3 + end + len("\n---")
+			if next := strings.Index(content[bodyStart:], "\n"); next != -1 {
+				bodyStart += next + 1
+			} else {
+				bodyStart = len(content)
+			}
+		}
+	}
+	insertAt := bodyStart
+	if loc := h1Re.FindStringIndex(content[bodyStart:]); loc != nil {
+		// Line after the heading, followed by a blank line
+		lineEnd := strings.Index(content[bodyStart+loc[0]:], "\n")
+		if lineEnd == -1 {
+			return content + "\n\n" + admonition.String(), true
+		}
+		insertAt = bodyStart + loc[0] + lineEnd + 1
+		return content[:insertAt] + "\n" + admonition.String() + strings.TrimLeft(content[insertAt:], "\n"), true
+	}
+	return content[:insertAt] + admonition.String() + content[insertAt:], true
+}
+
+// AddRedirects adds entries to the redirect_maps of the mkdocs-redirects
+// plugin in the content of mkdocs.yml, enabling the plugin when needed.
+// Keys and values are page paths relative to docs_dir. Existing entries for
+// the same page are replaced. Only the plugins entry is re-encoded; it
+// reports whether the content changed.
+func AddRedirects(content string, redirects map[string]string) (string, bool, error) {
+	// MkDocs only enables search by default when no plugins are configured
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err != nil {
+		return content, false, fmt.Errorf("parsing YAML: %w", err)
+	}
+	hasPlugins := len(root.Content) > 0 && MappingGet(root.Content[0], "plugins") != nil
+
+	return EditTopLevelKey(content, "plugins", yaml.SequenceNode, func(plugins *yaml.Node) (bool, error) {
+		var options *yaml.Node
+		for _, item := range plugins.Content {
+			switch {
+			case item.Kind == yaml.ScalarNode && item.Value == "redirects":
+				// "- redirects" without options
+				options = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				*item = *navItem("redirects", options)
+			case item.Kind == yaml.MappingNode && len(item.Content) == 2 && item.Content[0].Value == "redirects":
+				options = item.Content[1]
+				if options.Kind != yaml.MappingNode {
+					*options = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+				}
+			}
+		}
+		if options == nil {
+			if !hasPlugins {
+				plugins.Content = append(plugins.Content, ScalarNode("search"))
+			}
+			options = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			plugins.Content = append(plugins.Content, navItem("redirects", options))
+		}
+
+		maps := MappingGet(options, "redirect_maps")
+		if maps == nil || maps.Kind != yaml.MappingNode {
+			maps = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			MappingSet(options, "redirect_maps", maps)
+		}
+
+		from := make([]string, 0, len(redirects))
+		for page := range redirects {
+			from = append(from, page)
+		}
+		sort.Strings(from)
+
+		changed := false
+		for _, page := range from {
+			if existing := MappingGet(maps, page); existing != nil && existing.Value == redirects[page] {
+				continue
+			}
+			MappingSet(maps, page, ScalarNode(redirects[page]))
+			changed = true
+		}
+		return changed, nil
+	})
+}
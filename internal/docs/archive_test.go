@@ -0,0 +1,100 @@
+package docs
+
+import (
+	"testing"
+)
+
+func TestAddDeprecationNotice(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:    "below heading",
+			content: "---\ntitle: Old\n---\n# Old App\n\nText.\n",
+			expected: "---\ntitle: Old\n---\n# Old App\n\n!!! warning \"Deprecated\"\n\n" +
+				"    This app was removed.\n\nText.\n",
+		},
+		{
+			name:     "no heading",
+			content:  "---\ntitle: Old\n---\nText.\n",
+			expected: "---\ntitle: Old\n---\n!!! warning \"Deprecated\"\n\n    This app was removed.\n\nText.\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, changed := AddDeprecationNotice(tt.content, "This app was removed.")
+			if !changed || updated != tt.expected {
+				t.Errorf("AddDeprecationNotice = %q, %v; want %q", updated, changed, tt.expected)
+			}
+			if _, changed := AddDeprecationNotice(updated, "This app was removed."); changed {
+				t.Error("second AddDeprecationNotice changed the content")
+			}
+		})
+	}
+}
+
+func TestAddRedirects(t *testing.T) {
+	redirects := map[string]string{"apps/old.md": "archive/apps/old.md"}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:    "no plugins keeps search",
+			content: "site_name: Saltbox # site\n",
+			expected: `site_name: Saltbox # site
+plugins:
+  - search
+  - redirects:
+      redirect_maps:
+        apps/old.md: archive/apps/old.md
+`,
+		},
+		{
+			name: "existing map",
+			content: `plugins:
+  - search
+  - redirects:
+      redirect_maps:
+        apps/a.md: apps/b.md # renamed
+extra: {}
+`,
+			expected: `plugins:
+  - search
+  - redirects:
+      redirect_maps:
+        apps/a.md: apps/b.md # renamed
+        apps/old.md: archive/apps/old.md
+extra: {}
+`,
+		},
+		{
+			name:    "plugin without options",
+			content: "plugins:\n  - search\n  - redirects\n",
+			expected: `plugins:
+  - search
+  - redirects:
+      redirect_maps:
+        apps/old.md: archive/apps/old.md
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, changed, err := AddRedirects(tt.content, redirects)
+			if err != nil || !changed {
+				t.Fatalf("AddRedirects = %v, %v; want true, nil", changed, err)
+			}
+			if updated != tt.expected {
+				t.Errorf("AddRedirects =\n%s\nwant\n%s", updated, tt.expected)
+			}
+			if _, changed, err := AddRedirects(updated, redirects); err != nil || changed {
+				t.Errorf("second AddRedirects = %v, %v; want false, nil", changed, err)
+			}
+		})
+	}
+}
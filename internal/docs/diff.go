@@ -0,0 +1,232 @@
+package docs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around each diff hunk.
+const diffContext = 3
+
+// VariableChanges lists the role variables added, removed and changed
+// between two renderings of a variables section.
+type VariableChanges struct {
+	Added   []string
+	Removed []string
+	Changed []string // Variables whose rendered value changed
+}
+
+// Empty reports whether no variable changed.
+func (c VariableChanges) Empty() bool {
+	return len(c.Added)+len(c.Removed)+len(c.Changed) == 0
+}
+
+// DiffVariables compares the role variables listed in two renderings of a
+// variables section. Variables are recognized as "<role>_name: value"
+// lines, commented out or not, as rendered in the inventory code blocks;
+// the first occurrence of each variable is compared.
+func DiffVariables(oldSection, newSection, roleName string) VariableChanges {
+	oldNames, oldValues := sectionVariables(oldSection, roleName)
+	newNames, newValues := sectionVariables(newSection, roleName)
+
+	var changes VariableChanges
+	for _, name := range newNames {
+		oldValue, ok := oldValues[name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, name)
+		case oldValue != newValues[name]:
+			changes.Changed = append(changes.Changed, name)
+		}
+	}
+	for _, name := range oldNames {
+		if _, ok := newValues[name]; !ok {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+	return changes
+}
+
+// sectionVariables returns the role variables of a section in order of
+// appearance, with their rendered values.
+func sectionVariables(section, roleName string) ([]string, map[string]string) {
+	re := regexp.MustCompile(`(?m)^[ \t]*(?:#[ \t]*)?(` + regexp.QuoteMeta(roleName) + `_[A-Za-z0-9_]*)[ \t]*:(.*)$`)
+
+	var names []string
+	values := make(map[string]string)
+	for _, match := range re.FindAllStringSubmatch(section, -1) {
+		name := match[1]
+		if _, seen := values[name]; seen {
+			continue
+		}
+		names = append(names, name)
+		values[name] = strings.TrimSpace(match[2])
+	}
+	return names, values
+}
+
+// ChangedOverviewFields counts the fields that differ between two
+// renderings of an overview section. Fields are the cells of table rows,
+// or whole lines outside tables. A field replaced by another counts once,
+// as does each field added or removed.
+func ChangedOverviewFields(oldSection, newSection string) int {
+	changed := 0
+	removed, added := 0, 0
+	for _, op := range diffLines(overviewFields(oldSection), overviewFields(newSection)) {
+		switch op.kind {
+		case '-':
+			removed++
+		case '+':
+			added++
+		default:
+			changed += max(removed, added)
+			removed, added = 0, 0
+		}
+	}
+	return changed + max(removed, added)
+}
+
+// overviewFields returns the fields of an overview section in order.
+func overviewFields(section string) []string {
+	var fields []string
+	for _, line := range splitLines(section) {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "|") {
+			fields = append(fields, line)
+			continue
+		}
+		for _, cell := range strings.Split(strings.Trim(line, "|"), "|") {
+			cell = strings.TrimSpace(cell)
+			// Skip empty cells and header separators such as ":---:"
+			if strings.Trim(cell, ":-") == "" {
+				continue
+			}
+			fields = append(fields, cell)
+		}
+	}
+	return fields
+}
+
+// UnifiedDiff returns a unified diff of two texts, or "" when they are equal.
+func UnifiedDiff(oldName, newName, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+	ops := diffLines(oldLines, newLines)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName))
+
+	// Group the edit script into hunks of changes with their context
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		// Extend the hunk while changes are within 2*diffContext lines of each other
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContext {
+				break
+			}
+		}
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext, len(ops))
+
+		oldStart, newStart := ops[from].oldLine, ops[from].newLine
+		var oldCount, newCount int
+		var lines strings.Builder
+		for _, op := range ops[from:to] {
+			lines.WriteString(string(op.kind) + op.text + "\n")
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		builder.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount)))
+		builder.WriteString(lines.String())
+
+		start = to
+	}
+
+	return builder.String()
+}
+
+// diffOp is one line of an edit script: ' ' kept, '-' removed or '+' added.
+type diffOp struct {
+	kind    byte
+	text    string
+	oldLine int // 1-based line in the old text before this op
+	newLine int // 1-based line in the new text before this op
+}
+
+// diffLines returns the edit script turning a into b, from their longest
+// common subsequence.
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		op := diffOp{oldLine: i + 1, newLine: j + 1}
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			op.kind, op.text = ' ', a[i]
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			// Removals come before additions
+			op.kind, op.text = '-', a[i]
+			i++
+		default:
+			op.kind, op.text = '+', b[j]
+			j++
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// hunkRange formats the line range of a hunk header.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// Empty ranges name the line before the hunk
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// splitLines splits text into lines without their trailing newlines.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
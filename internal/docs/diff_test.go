@@ -0,0 +1,76 @@
+package docs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffVariables(t *testing.T) {
+	oldSection := "```yaml\nplex_role_web_port: \"32400\"\n# plex_role_lite: false\nplex_role_old: x\nplex2_role_web_port: \"1\"\n```\n"
+	newSection := "```yaml\nplex_role_web_port: \"32401\"\n# plex_role_lite: false\nplex_role_new: y\n```\n"
+
+	expected := VariableChanges{
+		Added:   []string{"plex_role_new"},
+		Removed: []string{"plex_role_old"},
+		Changed: []string{"plex_role_web_port"},
+	}
+	if got := DiffVariables(oldSection, newSection, "plex"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("DiffVariables = %+v, want %+v", got, expected)
+	}
+	if !DiffVariables(oldSection, oldSection, "plex").Empty() {
+		t.Error("DiffVariables of equal sections is not empty")
+	}
+}
+
+func TestChangedOverviewFields(t *testing.T) {
+	oldSection := "| Details | | |\n|:---:|:---:|:---:|\n| [Home](https://plex.tv) | [Docs](https://support.plex.tv) | [Github](https://github.com/plex) |\n\nPlex organizes media.\n"
+
+	tests := []struct {
+		name       string
+		newSection string
+		expected   int
+	}{
+		{"unchanged", oldSection, 0},
+		{"one link changed", strings.Replace(oldSection, "support.plex.tv", "docs.plex.tv", 1), 1},
+		{"link added and summary changed", strings.Replace(strings.Replace(oldSection, " |\n\n", " | [Docker](https://hub.docker.com) |\n\n", 1), "organizes", "streams", 1), 2},
+		{"link removed", strings.Replace(oldSection, " [Github](https://github.com/plex) |", "", 1), 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChangedOverviewFields(oldSection, tt.newSection); got != tt.expected {
+				t.Errorf("ChangedOverviewFields = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldText := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	newText := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	expected := `--- a/doc.md
++++ b/doc.md
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -10,3 +10,4 @@
+ j
+ k
+ l
++m
+`
+	if got := UnifiedDiff("a/doc.md", "b/doc.md", oldText, newText); got != expected {
+		t.Errorf("UnifiedDiff =\n%s\nwant\n%s", got, expected)
+	}
+	if got := UnifiedDiff("a", "b", oldText, oldText); got != "" {
+		t.Errorf("UnifiedDiff of equal texts = %q", got)
+	}
+	if got := UnifiedDiff("a", "b", "", "x\n"); got != "--- a\n+++ b\n@@ -0,0 +1 @@\n+x\n" {
+		t.Errorf("UnifiedDiff from empty = %q", got)
+	}
+}
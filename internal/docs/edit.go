@@ -0,0 +1,322 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// saltboxAutomationKey is the frontmatter key holding automation settings.
+const saltboxAutomationKey = "saltbox_automation"
+
+// EditSaltboxAutomation edits the saltbox_automation frontmatter of a document
+// through its yaml.Node tree and splices the result back into doc.Content.
+//
+// Only the lines of the saltbox_automation entry are re-encoded; every other
+// frontmatter key and the document body stay byte-identical. The entry (and
+// the frontmatter itself) is created when missing. edit receives the
+// saltbox_automation mapping node and reports whether it changed anything;
+// when it did not, the document is left untouched.
+func (d *Document) EditSaltboxAutomation(edit func(node *yaml.Node) (bool, error)) (bool, error) {
+	before, frontmatter, after := "---\n", "", "---\n"+d.Content
+	if strings.HasPrefix(d.Content, "---") {
+		// Frontmatter lines, between the opening and closing delimiters
+		openEnd := strings.Index(d.Content, "\n") + 1
+		closeIdx := strings.Index(d.Content[3:], "\n---")
+		if openEnd == 0 || closeIdx == -1 {
+			return false, fmt.Errorf("unclosed frontmatter: missing closing ---")
+		}
+		fmEnd := 3 + closeIdx + 1 // start of the closing delimiter line
+		if fmEnd < openEnd {
+			fmEnd = openEnd
+		}
+		before, frontmatter, after = d.Content[:openEnd], d.Content[openEnd:fmEnd], d.Content[fmEnd:]
+	}
+
+	updated, changed, err := EditTopLevelKey(frontmatter, saltboxAutomationKey, yaml.MappingNode, edit)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	content := before + updated + after
+	fm, body, err := ParseFrontmatter(content)
+	if err != nil {
+		return false, fmt.Errorf("re-parsing frontmatter: %w", err)
+	}
+
+	d.Content = content
+	d.Frontmatter = fm
+	d.Body = body
+	return true, nil
+}
+
+// EditTopLevelKey edits the value of a top-level key of a YAML document
+// through its yaml.Node tree and returns the updated text.
+//
+// Only the lines of that entry are re-encoded; the rest of the text stays
+// byte-identical. A missing entry, or one whose value is not of the given
+// kind, starts out empty and is appended to the document. edit reports
+// whether it changed anything; when it did not, text is returned as is.
+func EditTopLevelKey(text, key string, kind yaml.Kind, edit func(node *yaml.Node) (bool, error)) (string, bool, error) {
+	before, segment, after, err := splitTopLevelKey(text, key)
+	if err != nil {
+		return text, false, err
+	}
+
+	empty := func() *yaml.Node {
+		if kind == yaml.SequenceNode {
+			return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	var root yaml.Node
+	node := empty()
+	if segment != "" {
+		if err := yaml.Unmarshal([]byte(segment), &root); err != nil {
+			return text, false, fmt.Errorf("parsing %s: %w", key, err)
+		}
+		if value := MappingGet(root.Content[0], key); value != nil {
+			if value.Kind != kind {
+				// "key:" with no value, or a value of another kind
+				*value = *empty()
+			}
+			node = value
+		}
+	} else {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+		MappingSet(root.Content[0], key, node)
+		if before != "" && !strings.HasSuffix(before, "\n") {
+			before += "\n"
+		}
+	}
+
+	changed, err := edit(node)
+	if err != nil || !changed {
+		return text, false, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return text, false, fmt.Errorf("encoding %s: %w", key, err)
+	}
+	if err := enc.Close(); err != nil {
+		return text, false, fmt.Errorf("encoding %s: %w", key, err)
+	}
+
+	return before + buf.String() + after, true, nil
+}
+
+// splitTopLevelKey splits a YAML document into the text before the entry of
+// a top-level key, the entry itself and the text after it. Comments and
+// blank lines directly after the entry are kept in after. When there is no
+// entry, segment is empty and the split point is the end of the text.
+func splitTopLevelKey(text, key string) (before, segment, after string, err error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &root); err != nil {
+		return "", "", "", fmt.Errorf("parsing YAML: %w", err)
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return text, "", "", nil
+	}
+
+	// Find the entry and the next top-level key
+	mapping := root.Content[0]
+	startLine, endLine := 0, 0
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if startLine != 0 {
+			endLine = mapping.Content[i].Line
+			break
+		}
+		if mapping.Content[i].Value == key {
+			startLine = mapping.Content[i].Line
+		}
+	}
+	if startLine == 0 {
+		return text, "", "", nil
+	}
+
+	lines := strings.SplitAfter(text, "\n")
+	if endLine == 0 {
+		endLine = len(lines) + 1
+	}
+
+	// Leave trailing comments and blank lines in place
+	for endLine-1 > startLine {
+		trimmed := strings.TrimSpace(lines[endLine-2])
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		endLine--
+	}
+
+	segStart := len(strings.Join(lines[:startLine-1], ""))
+	segEnd := len(strings.Join(lines[:endLine-1], ""))
+	return text[:segStart], text[segStart:segEnd], text[segEnd:], nil
+}
+
+// SetSaltboxAutomation sets the saltbox_automation value at a dotted path
+// (e.g. "sections.inventory" or "app_links.0.url") to a YAML value.
+// Numeric segments index lists; an index equal to the list length appends.
+// Missing mappings and lists along the path are created. It reports whether
+// the document changed.
+func (d *Document) SetSaltboxAutomation(path, value string) (bool, error) {
+	segments, err := ParsePath(path)
+	if err != nil {
+		return false, err
+	}
+	node, err := ParseValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	return d.EditSaltboxAutomation(func(root *yaml.Node) (bool, error) {
+		return SetPath(root, segments, node)
+	})
+}
+
+// ParsePath splits a dotted frontmatter path into its segments.
+func ParsePath(path string) ([]string, error) {
+	path = strings.TrimPrefix(path, saltboxAutomationKey+".")
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid path %q: empty segment", path)
+		}
+	}
+	return segments, nil
+}
+
+// ParseValue parses a YAML value such as "false", "[a, b]" or "{name: x}".
+// Lists and mappings are converted to block style.
+func ParseValue(value string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+		return nil, fmt.Errorf("parsing value: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return ScalarNode(value), nil
+	}
+
+	node := doc.Content[0]
+	var blockStyle func(*yaml.Node)
+	blockStyle = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode || n.Kind == yaml.SequenceNode {
+			n.Style &^= yaml.FlowStyle
+		}
+		for _, child := range n.Content {
+			blockStyle(child)
+		}
+	}
+	blockStyle(node)
+	return node, nil
+}
+
+// SetPath sets the value at path below node, creating intermediate
+// mappings and lists. It reports whether the value changed.
+func SetPath(node *yaml.Node, path []string, value *yaml.Node) (bool, error) {
+	key, rest := path[0], path[1:]
+
+	var current *yaml.Node
+	switch node.Kind {
+	case yaml.MappingNode:
+		current = MappingGet(node, key)
+	case yaml.SequenceNode:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index > len(node.Content) {
+			return false, fmt.Errorf("invalid list index %q (list has %d items)", key, len(node.Content))
+		}
+		if index < len(node.Content) {
+			current = node.Content[index]
+		}
+	default:
+		return false, fmt.Errorf("cannot set %q: parent is not a mapping or list", key)
+	}
+
+	if len(rest) > 0 {
+		if current != nil && current.Kind != yaml.MappingNode && current.Kind != yaml.SequenceNode {
+			return false, fmt.Errorf("cannot set %q: %q is not a mapping or list", strings.Join(rest, "."), key)
+		}
+		if current == nil {
+			current = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if _, err := strconv.Atoi(rest[0]); err == nil {
+				current = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			}
+			setChild(node, key, current)
+		}
+		return SetPath(current, rest, value)
+	}
+
+	if current != nil && sameNode(current, value) {
+		return false, nil
+	}
+	setChild(node, key, value)
+	return true, nil
+}
+
+// setChild sets a mapping key or list index; the index has been validated.
+func setChild(node *yaml.Node, key string, value *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		MappingSet(node, key, value)
+		return
+	}
+	index, _ := strconv.Atoi(key)
+	if index == len(node.Content) {
+		node.Content = append(node.Content, value)
+		return
+	}
+	node.Content[index] = value
+}
+
+// sameNode reports whether two nodes encode to the same YAML.
+func sameNode(a, b *yaml.Node) bool {
+	encode := func(n *yaml.Node) string {
+		bare := *n
+		bare.HeadComment, bare.LineComment, bare.FootComment = "", "", ""
+		out, err := yaml.Marshal(&bare)
+		if err != nil {
+			return ""
+		}
+		return string(out)
+	}
+	return encode(a) == encode(b)
+}
+
+// MappingGet returns the value node for key in a mapping node, or nil.
+func MappingGet(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// MappingSet sets key to value in a mapping node, replacing an existing value
+// in place (keeping its key comments) or appending a new entry.
+func MappingSet(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			value.LineComment = node.Content[i+1].LineComment
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// ScalarNode returns a plain string scalar node.
+func ScalarNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
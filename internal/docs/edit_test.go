@@ -0,0 +1,212 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const editTestDoc = `---
+title: "Plex"   # quoted on purpose
+hide:
+  - tags
+saltbox_automation:
+  sections:
+    inventory: false # hand-written comment
+  app_links:
+    - name: Manual
+      url: https://support.plex.tv
+# comment after the block
+
+tags: [media, streaming]
+---
+# Plex
+
+Body text.
+`
+
+func TestEditSaltboxAutomation(t *testing.T) {
+	doc := &Document{Content: editTestDoc}
+	changed, err := doc.EditSaltboxAutomation(func(node *yaml.Node) (bool, error) {
+		return SetPath(node, []string{"project_description", "summary"}, ScalarNode("Media server"))
+	})
+	if err != nil || !changed {
+		t.Fatalf("EditSaltboxAutomation = %v, %v; want true, nil", changed, err)
+	}
+
+	expected := strings.Replace(editTestDoc, "      url: https://support.plex.tv\n",
+		"      url: https://support.plex.tv\n  project_description:\n    summary: Media server\n", 1)
+	if doc.Content != expected {
+		t.Errorf("content =\n%s\nwant\n%s", doc.Content, expected)
+	}
+	if doc.Frontmatter == nil || doc.Frontmatter.SaltboxAutomation.ProjectDescription.Summary != "Media server" {
+		t.Error("frontmatter was not re-parsed after the edit")
+	}
+}
+
+func TestEditSaltboxAutomation_Unchanged(t *testing.T) {
+	doc := &Document{Content: editTestDoc}
+	changed, err := doc.EditSaltboxAutomation(func(node *yaml.Node) (bool, error) {
+		return SetPath(node, []string{"app_links", "0", "name"}, ScalarNode("Manual"))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || doc.Content != editTestDoc {
+		t.Error("setting an identical value should leave the document untouched")
+	}
+}
+
+func TestSetPath_Errors(t *testing.T) {
+	tests := map[string][]string{
+		"index out of range":  {"app_links", "5"},
+		"scalar parent":       {"app_links", "0", "url", "nested"},
+		"non-numeric index":   {"app_links", "name"},
+		"list under a scalar": {"sections", "inventory", "0"},
+	}
+
+	for name, path := range tests {
+		doc := &Document{Content: editTestDoc}
+		_, err := doc.EditSaltboxAutomation(func(node *yaml.Node) (bool, error) {
+			return SetPath(node, path, ScalarNode("x"))
+		})
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if doc.Content != editTestDoc {
+			t.Errorf("%s: document modified on error", name)
+		}
+	}
+}
+
+func TestSetSaltboxAutomation(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		value    string
+		expected string // replacement for the saltbox_automation block
+	}{
+		{
+			name:  "replace scalar keeps comment",
+			path:  "sections.inventory",
+			value: "true",
+			expected: `saltbox_automation:
+  sections:
+    inventory: true # hand-written comment
+  app_links:
+    - name: Manual
+      url: https://support.plex.tv
+`,
+		},
+		{
+			name:  "create nested mapping",
+			path:  "project_description.categories",
+			value: "[Media, Streaming]",
+			expected: `saltbox_automation:
+  sections:
+    inventory: false # hand-written comment
+  app_links:
+    - name: Manual
+      url: https://support.plex.tv
+  project_description:
+    categories:
+      - Media
+      - Streaming
+`,
+		},
+		{
+			name:  "append to list",
+			path:  "saltbox_automation.app_links.1",
+			value: "{name: Home, url: https://www.plex.tv, type: home}",
+			expected: `saltbox_automation:
+  sections:
+    inventory: false # hand-written comment
+  app_links:
+    - name: Manual
+      url: https://support.plex.tv
+    - name: Home
+      url: https://www.plex.tv
+      type: home
+`,
+		},
+	}
+
+	original := `saltbox_automation:
+  sections:
+    inventory: false # hand-written comment
+  app_links:
+    - name: Manual
+      url: https://support.plex.tv
+`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := &Document{Content: editTestDoc}
+			changed, err := doc.SetSaltboxAutomation(tt.path, tt.value)
+			if err != nil || !changed {
+				t.Fatalf("SetSaltboxAutomation = %v, %v; want true, nil", changed, err)
+			}
+
+			expected := strings.Replace(editTestDoc, original, tt.expected, 1)
+			if doc.Content != expected {
+				t.Errorf("content =\n%s\nwant\n%s", doc.Content, expected)
+			}
+			if doc.Frontmatter == nil || doc.Frontmatter.SaltboxAutomation == nil {
+				t.Error("frontmatter was not re-parsed")
+			}
+		})
+	}
+}
+
+func TestSetSaltboxAutomation_Unchanged(t *testing.T) {
+	doc := &Document{Content: editTestDoc}
+	changed, err := doc.SetSaltboxAutomation("sections.inventory", "false")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed || doc.Content != editTestDoc {
+		t.Error("setting an identical value should leave the document untouched")
+	}
+}
+
+func TestSetSaltboxAutomation_Errors(t *testing.T) {
+	tests := map[string]string{
+		"app_links.5":             "x",     // index out of range
+		"app_links.0.url.nested":  "x",     // url is a scalar
+		"sections..inventory":     "true",  // empty segment
+		"sections.inventory":      "maybe", // not a bool
+		"app_links.name":          "x",     // non-numeric index
+		"project_description.[x]": "[",     // invalid YAML
+	}
+
+	for path, value := range tests {
+		doc := &Document{Content: editTestDoc}
+		if _, err := doc.SetSaltboxAutomation(path, value); err == nil {
+			t.Errorf("SetSaltboxAutomation(%q, %q) expected error", path, value)
+		}
+		if doc.Content != editTestDoc {
+			t.Errorf("SetSaltboxAutomation(%q, %q) modified the document on error", path, value)
+		}
+	}
+}
+
+func TestSetSaltboxAutomation_CreatesBlock(t *testing.T) {
+	tests := map[string]string{
+		"no frontmatter":    "# Plex\n",
+		"no automation key": "---\ntitle: Plex\n---\n# Plex\n",
+	}
+	expected := map[string]string{
+		"no frontmatter":    "---\nsaltbox_automation:\n  sections:\n    overview: false\n---\n# Plex\n",
+		"no automation key": "---\ntitle: Plex\nsaltbox_automation:\n  sections:\n    overview: false\n---\n# Plex\n",
+	}
+
+	for name, content := range tests {
+		doc := &Document{Content: content}
+		if _, err := doc.SetSaltboxAutomation("sections.overview", "false"); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if doc.Content != expected[name] {
+			t.Errorf("%s: content =\n%q\nwant\n%q", name, doc.Content, expected[name])
+		}
+	}
+}
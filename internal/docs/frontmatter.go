@@ -30,6 +30,7 @@ type SectionsConfig struct {
 
 // InventoryConfig controls the inventory section generation.
 type InventoryConfig struct {
+	Template         string            `yaml:"template"`
 	ShowSections     []string          `yaml:"show_sections"`
 	HideSections     []string          `yaml:"hide_sections"`
 	ExampleOverrides map[string]string `yaml:"example_overrides"`
@@ -136,6 +137,15 @@ func (c *SaltboxAutomationConfig) ShouldShowSection(sectionName string) bool {
 	return true
 }
 
+// InventoryTemplate returns the inventory template variant selected for this document.
+// An empty string means the base inventory template.
+func (c *SaltboxAutomationConfig) InventoryTemplate() string {
+	if c == nil {
+		return ""
+	}
+	return strings.TrimSpace(c.Inventory.Template)
+}
+
 // GetExampleOverride returns the example override for a variable, if any.
 func (c *SaltboxAutomationConfig) GetExampleOverride(varName string) (string, bool) {
 	if c == nil || c.Inventory.ExampleOverrides == nil {
@@ -0,0 +1,165 @@
+package docs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Nav grouping modes.
+const (
+	NavGroupAlpha    = "alpha"    // one alphabetized list
+	NavGroupCategory = "category" // grouped by the first project_description category
+)
+
+// NavOtherCategory is the group of apps without a category.
+const NavOtherCategory = "Other"
+
+// h1Re matches a level 1 ATX heading.
+var h1Re = regexp.MustCompile(`(?m)^#\s+(.+?)\s*#*\s*$`)
+
+// NavEntry is an app page listed in the MkDocs nav.
+type NavEntry struct {
+	Title    string // Page title
+	Path     string // Page path relative to the MkDocs docs_dir
+	Category string // Group in category mode, "" for NavOtherCategory
+}
+
+// DocumentTitle returns the title of a page: the frontmatter title, the
+// first level 1 heading, or fallback.
+func DocumentTitle(doc *Document, fallback string) string {
+	if doc.Frontmatter != nil && doc.Frontmatter.Raw != "" {
+		var fm struct {
+			Title string `yaml:"title"`
+		}
+		if err := yaml.Unmarshal([]byte(doc.Frontmatter.Raw), &fm); err == nil && fm.Title != "" {
+			return fm.Title
+		}
+	}
+	if match := h1Re.FindStringSubmatch(doc.Body); match != nil {
+		return match[1]
+	}
+	return fallback
+}
+
+// DocumentCategory returns the first project_description category of a page, or "".
+func DocumentCategory(doc *Document) string {
+	if doc.Frontmatter == nil || doc.Frontmatter.SaltboxAutomation == nil {
+		return ""
+	}
+	pd := doc.Frontmatter.SaltboxAutomation.ProjectDescription
+	if pd == nil || len(pd.Categories) == 0 {
+		return ""
+	}
+	return pd.Categories[0]
+}
+
+// BuildNav builds the nav items for entries: "- Title: path" items sorted
+// by title (case-insensitive), optionally grouped by category. Groups are
+// sorted by name with NavOtherCategory last.
+func BuildNav(entries []NavEntry, groupBy string) []*yaml.Node {
+	sorted := append([]NavEntry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := strings.ToLower(sorted[i].Title), strings.ToLower(sorted[j].Title)
+		if a != b {
+			return a < b
+		}
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	if groupBy != NavGroupCategory {
+		items := make([]*yaml.Node, 0, len(sorted))
+		for _, entry := range sorted {
+			items = append(items, navItem(entry.Title, ScalarNode(entry.Path)))
+		}
+		return items
+	}
+
+	groups := make(map[string]*yaml.Node)
+	var names []string
+	for _, entry := range sorted {
+		category := entry.Category
+		if category == "" {
+			category = NavOtherCategory
+		}
+		group, ok := groups[category]
+		if !ok {
+			group = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			groups[category] = group
+			names = append(names, category)
+		}
+		group.Content = append(group.Content, navItem(entry.Title, ScalarNode(entry.Path)))
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		if (names[i] == NavOtherCategory) != (names[j] == NavOtherCategory) {
+			return names[j] == NavOtherCategory
+		}
+		return strings.ToLower(names[i]) < strings.ToLower(names[j])
+	})
+
+	items := make([]*yaml.Node, 0, len(names))
+	for _, name := range names {
+		items = append(items, navItem(name, groups[name]))
+	}
+	return items
+}
+
+// navItem returns a single-key "title: value" nav mapping.
+func navItem(title string, value *yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{ScalarNode(title), value}}
+}
+
+// UpdateNav replaces the nav section reached by following titles (e.g.
+// ["Apps"] or ["Sandbox", "Apps"]) in the content of mkdocs.yml with items.
+// Missing sections along the path are appended. Only the nav entry is
+// re-encoded; the rest of the file stays byte-identical. It reports whether
+// the content changed.
+func UpdateNav(content string, titles []string, items []*yaml.Node) (string, bool, error) {
+	if len(titles) == 0 {
+		return content, false, fmt.Errorf("nav path is empty")
+	}
+
+	return EditTopLevelKey(content, "nav", yaml.SequenceNode, func(nav *yaml.Node) (bool, error) {
+		section := nav
+		for i, title := range titles {
+			next, err := navChild(section, title)
+			if err != nil {
+				return false, fmt.Errorf("nav %s: %w", strings.Join(titles[:i+1], " > "), err)
+			}
+			section = next
+		}
+
+		replacement := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: items}
+		if sameNode(section, replacement) {
+			return false, nil
+		}
+		section.Content = items
+		return true, nil
+	})
+}
+
+// navChild returns the item list of the nav section with the given title,
+// appending an empty section when there is none.
+func navChild(list *yaml.Node, title string) (*yaml.Node, error) {
+	for _, item := range list.Content {
+		if item.Kind != yaml.MappingNode || len(item.Content) != 2 || item.Content[0].Value != title {
+			continue
+		}
+		value := item.Content[1]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			// "- Apps:" with no items yet
+			*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		if value.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("%q is a page, not a section", title)
+		}
+		return value, nil
+	}
+
+	section := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	list.Content = append(list.Content, navItem(title, section))
+	return section, nil
+}
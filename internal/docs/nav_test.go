@@ -0,0 +1,127 @@
+package docs
+
+import (
+	"strings"
+	"testing"
+)
+
+const navTestConfig = `site_name: Saltbox   # kept as is
+markdown_extensions:
+  - pymdownx.emoji:
+      emoji_index: !!python/name:material.extensions.emoji.twemoji
+nav:
+  - Home: index.md
+  - Apps:
+      - Stale: apps/stale.md
+  - Reference:
+      - Docker+: reference/docker.md
+
+# trailing comment
+extra: {}
+`
+
+func TestUpdateNav(t *testing.T) {
+	entries := []NavEntry{
+		{Title: "sonarr", Path: "apps/sonarr.md", Category: "Arrs"},
+		{Title: "Plex", Path: "apps/plex.md", Category: "Media"},
+		{Title: "Authelia", Path: "apps/authelia.md"},
+		{Title: "Radarr", Path: "apps/radarr.md", Category: "Arrs"},
+	}
+
+	tests := []struct {
+		name     string
+		groupBy  string
+		titles   []string
+		expected string
+	}{
+		{
+			name:    "alphabetized",
+			groupBy: NavGroupAlpha,
+			titles:  []string{"Apps"},
+			expected: `nav:
+  - Home: index.md
+  - Apps:
+      - Authelia: apps/authelia.md
+      - Plex: apps/plex.md
+      - Radarr: apps/radarr.md
+      - sonarr: apps/sonarr.md
+  - Reference:
+      - Docker+: reference/docker.md
+`,
+		},
+		{
+			name:    "grouped by category in a new section",
+			groupBy: NavGroupCategory,
+			titles:  []string{"Sandbox", "Apps"},
+			expected: `nav:
+  - Home: index.md
+  - Apps:
+      - Stale: apps/stale.md
+  - Reference:
+      - Docker+: reference/docker.md
+  - Sandbox:
+      - Apps:
+          - Arrs:
+              - Radarr: apps/radarr.md
+              - sonarr: apps/sonarr.md
+          - Media:
+              - Plex: apps/plex.md
+          - Other:
+              - Authelia: apps/authelia.md
+`,
+		},
+	}
+
+	original := `nav:
+  - Home: index.md
+  - Apps:
+      - Stale: apps/stale.md
+  - Reference:
+      - Docker+: reference/docker.md
+`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, changed, err := UpdateNav(navTestConfig, tt.titles, BuildNav(entries, tt.groupBy))
+			if err != nil || !changed {
+				t.Fatalf("UpdateNav = %v, %v; want true, nil", changed, err)
+			}
+
+			expected := strings.Replace(navTestConfig, original, tt.expected, 1)
+			if updated != expected {
+				t.Errorf("UpdateNav =\n%s\nwant\n%s", updated, expected)
+			}
+
+			// Running again is a no-op
+			if _, changed, err := UpdateNav(updated, tt.titles, BuildNav(entries, tt.groupBy)); err != nil || changed {
+				t.Errorf("second UpdateNav = %v, %v; want false, nil", changed, err)
+			}
+		})
+	}
+}
+
+func TestUpdateNav_PageInPath(t *testing.T) {
+	if _, _, err := UpdateNav(navTestConfig, []string{"Home"}, nil); err == nil {
+		t.Error("expected an error when the nav path points at a page")
+	}
+}
+
+func TestDocumentTitle(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"---\ntitle: Plex Media Server\n---\n# Plex\n", "Plex Media Server"},
+		{"---\nhide: [toc]\n---\n# Plex\n", "Plex"},
+		{"Some text\n\n## Not a title\n", "plex"},
+	}
+	for _, tt := range tests {
+		fm, body, err := ParseFrontmatter(tt.content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		doc := &Document{Content: tt.content, Frontmatter: fm, Body: body}
+		if got := DocumentTitle(doc, "plex"); got != tt.expected {
+			t.Errorf("DocumentTitle(%q) = %q, want %q", tt.content, got, tt.expected)
+		}
+	}
+}
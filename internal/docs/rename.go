@@ -0,0 +1,40 @@
+package docs
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rename is a name rewritten by a role migration.
+type Rename struct {
+	Old string
+	New string
+}
+
+// RenameExampleOverrides rewrites the inventory.example_overrides keys of a
+// document that start with "<oldRole>_" to start with "<newRole>_". Keys of
+// other roles are left alone. It returns the renamed keys in document order.
+func (d *Document) RenameExampleOverrides(oldRole, newRole string) ([]Rename, error) {
+	var renames []Rename
+	_, err := d.EditSaltboxAutomation(func(root *yaml.Node) (bool, error) {
+		overrides := MappingGet(MappingGet(root, "inventory"), "example_overrides")
+		if overrides == nil || overrides.Kind != yaml.MappingNode {
+			return false, nil
+		}
+		for i := 0; i+1 < len(overrides.Content); i += 2 {
+			key := overrides.Content[i]
+			if !strings.HasPrefix(key.Value, oldRole+"_") {
+				continue
+			}
+			renamed := newRole + "_" + strings.TrimPrefix(key.Value, oldRole+"_")
+			renames = append(renames, Rename{Old: key.Value, New: renamed})
+			key.Value = renamed
+		}
+		return len(renames) > 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renames, nil
+}
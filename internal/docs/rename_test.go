@@ -0,0 +1,47 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRenameExampleOverrides(t *testing.T) {
+	content := `---
+title: Organizr
+saltbox_automation:
+  inventory:
+    example_overrides:
+      organizr_role_web_subdomain: '"home"' # custom
+      organizr2_role_docker_image: x
+      other_role_name: y
+---
+# Organizr
+`
+	fm, body, err := ParseFrontmatter(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &Document{Content: content, Frontmatter: fm, Body: body}
+
+	renames, err := doc.RenameExampleOverrides("organizr", "organizr2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Rename{{Old: "organizr_role_web_subdomain", New: "organizr2_role_web_subdomain"}}
+	if !reflect.DeepEqual(renames, expected) {
+		t.Errorf("renames = %v, want %v", renames, expected)
+	}
+
+	overrides := doc.Frontmatter.SaltboxAutomation.Inventory.ExampleOverrides
+	if overrides["organizr2_role_web_subdomain"] != `"home"` || overrides["other_role_name"] != "y" {
+		t.Errorf("example_overrides = %v", overrides)
+	}
+	if _, ok := overrides["organizr_role_web_subdomain"]; ok {
+		t.Error("old key still present")
+	}
+
+	// Nothing left to rename
+	if renames, err := doc.RenameExampleOverrides("organizr", "organizr2"); err != nil || len(renames) != 0 {
+		t.Errorf("second rename = %v, %v; want none", renames, err)
+	}
+}
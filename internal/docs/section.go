@@ -3,6 +3,7 @@ package docs
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -111,38 +112,63 @@ func CreateManagedSection(sectionName, content string) string {
 	return builder.String()
 }
 
-// ValidateManagedSections checks that all managed sections have matching markers.
-func ValidateManagedSections(content string) []string {
-	var errors []string
-
-	// Find all BEGIN markers
-	beginRe := regexp.MustCompile(`<!-- BEGIN ([^>]+) -->`)
-	endRe := regexp.MustCompile(`<!-- END ([^>]+) -->`)
-
-	begins := beginRe.FindAllStringSubmatch(content, -1)
-	ends := endRe.FindAllStringSubmatch(content, -1)
+// MarkerProblem is an unmatched managed section marker.
+type MarkerProblem struct {
+	Line    int // 1-based line of the marker
+	Message string
+}
 
-	beginNames := make(map[string]bool)
-	endNames := make(map[string]bool)
+var (
+	beginMarkerRe = regexp.MustCompile(`<!-- BEGIN ([^>]+) -->`)
+	endMarkerRe   = regexp.MustCompile(`<!-- END ([^>]+) -->`)
+)
 
-	for _, match := range begins {
-		beginNames[match[1]] = true
-	}
-	for _, match := range ends {
-		endNames[match[1]] = true
+// FindMarkerProblems returns the BEGIN markers without an END marker and the
+// END markers without a BEGIN marker, at the line of the first such marker,
+// in line order.
+func FindMarkerProblems(content string) []MarkerProblem {
+	beginLines := make(map[string]int)
+	endLines := make(map[string]int)
+
+	for i, line := range strings.Split(content, "\n") {
+		for _, match := range beginMarkerRe.FindAllStringSubmatch(line, -1) {
+			if _, ok := beginLines[match[1]]; !ok {
+				beginLines[match[1]] = i + 1
+			}
+		}
+		for _, match := range endMarkerRe.FindAllStringSubmatch(line, -1) {
+			if _, ok := endLines[match[1]]; !ok {
+				endLines[match[1]] = i + 1
+			}
+		}
 	}
 
-	// Check for unmatched markers
-	for name := range beginNames {
-		if !endNames[name] {
-			errors = append(errors, fmt.Sprintf("missing END marker for %q", name))
+	var problems []MarkerProblem
+	for name, line := range beginLines {
+		if _, ok := endLines[name]; !ok {
+			problems = append(problems, MarkerProblem{Line: line, Message: fmt.Sprintf("missing END marker for %q", name)})
 		}
 	}
-	for name := range endNames {
-		if !beginNames[name] {
-			errors = append(errors, fmt.Sprintf("missing BEGIN marker for %q", name))
+	for name, line := range endLines {
+		if _, ok := beginLines[name]; !ok {
+			problems = append(problems, MarkerProblem{Line: line, Message: fmt.Sprintf("missing BEGIN marker for %q", name)})
 		}
 	}
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Message < problems[j].Message
+	})
+
+	return problems
+}
 
+// ValidateManagedSections checks that all managed sections have matching markers.
+func ValidateManagedSections(content string) []string {
+	var errors []string
+	for _, problem := range FindMarkerProblems(content) {
+		errors = append(errors, problem.Message)
+	}
 	return errors
 }
@@ -0,0 +1,36 @@
+package docs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindMarkerProblems(t *testing.T) {
+	content := `# Plex
+
+<!-- BEGIN SALTBOX MANAGED VARIABLES SECTION -->
+vars
+<!-- END SALTBOX MANAGED VARIABLES SECTION -->
+
+<!-- BEGIN SALTBOX MANAGED OVERVIEW SECTION -->
+table
+
+<!-- END SALTBOX MANAGED CLI SECTION -->
+`
+	want := []MarkerProblem{
+		{Line: 7, Message: `missing END marker for "SALTBOX MANAGED OVERVIEW SECTION"`},
+		{Line: 10, Message: `missing BEGIN marker for "SALTBOX MANAGED CLI SECTION"`},
+	}
+	if got := FindMarkerProblems(content); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindMarkerProblems() = %v, want %v", got, want)
+	}
+
+	wantMessages := []string{want[0].Message, want[1].Message}
+	if got := ValidateManagedSections(content); !reflect.DeepEqual(got, wantMessages) {
+		t.Errorf("ValidateManagedSections() = %v, want %v", got, wantMessages)
+	}
+
+	if got := FindMarkerProblems("<!-- BEGIN A -->\n<!-- END A -->\n"); got != nil {
+		t.Errorf("FindMarkerProblems() on matched markers = %v, want none", got)
+	}
+}
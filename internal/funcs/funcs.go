@@ -0,0 +1,329 @@
+// Package funcs provides the template function library shared by all template kinds
+// (inventory, overview, CLI help and scaffold).
+package funcs
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// slugInvalidRe matches characters removed from heading slugs.
+	slugInvalidRe = regexp.MustCompile(`[^\w\s-]`)
+	// slugSeparatorRe matches runs of whitespace and hyphens collapsed in heading slugs.
+	slugSeparatorRe = regexp.MustCompile(`[-\s]+`)
+
+	// markdownEscaper escapes characters with special meaning in inline markdown.
+	markdownEscaper = strings.NewReplacer(
+		`\`, `\\`,
+		"`", "\\`",
+		`*`, `\*`,
+		`_`, `\_`,
+		`[`, `\[`,
+		`]`, `\]`,
+		`<`, `\<`,
+		`>`, `\>`,
+		`|`, `\|`,
+	)
+
+	// admonitionAliases maps MkDocs Material admonition qualifiers to their canonical type.
+	admonitionAliases = map[string]string{
+		"note":      "note",
+		"abstract":  "abstract",
+		"summary":   "abstract",
+		"tldr":      "abstract",
+		"info":      "info",
+		"todo":      "info",
+		"tip":       "tip",
+		"hint":      "tip",
+		"important": "tip",
+		"success":   "success",
+		"check":     "success",
+		"done":      "success",
+		"question":  "question",
+		"help":      "question",
+		"faq":       "question",
+		"warning":   "warning",
+		"warn":      "warning",
+		"caution":   "warning",
+		"attention": "warning",
+		"failure":   "failure",
+		"fail":      "failure",
+		"missing":   "failure",
+		"danger":    "danger",
+		"error":     "danger",
+		"bug":       "bug",
+		"example":   "example",
+		"quote":     "quote",
+		"cite":      "quote",
+	}
+)
+
+// Map returns the shared template function map.
+// Functions take the piped value as their last argument, so they can be
+// used as {{ .Value | fn "arg" }}.
+func Map() template.FuncMap {
+	return template.FuncMap{
+		// String functions
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"title":      Title,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": TrimPrefix,
+		"trimSuffix": TrimSuffix,
+		"replace":    Replace,
+		"contains":   Contains,
+		"hasPrefix":  HasPrefix,
+		"hasSuffix":  HasSuffix,
+		"indent":     Indent,
+		"plural":     Plural,
+
+		// List functions
+		"split":     Split,
+		"join":      Join,
+		"sortAlpha": SortAlpha,
+		"uniq":      Uniq,
+		"keys":      Keys,
+
+		// Markdown functions
+		"escapeMarkdown":  EscapeMarkdown,
+		"escapeTableCell": EscapeTableCell,
+		"codeSpan":        CodeSpan,
+		"slugify":         Slugify,
+		"admonition":      Admonition,
+		"admonitionType":  AdmonitionType,
+
+		// YAML functions
+		"yamlQuote": YAMLQuote,
+	}
+}
+
+// Merge returns a new function map containing the shared functions and extra.
+// Functions in extra take precedence over shared functions with the same name.
+func Merge(extra template.FuncMap) template.FuncMap {
+	merged := Map()
+	for name, fn := range extra {
+		merged[name] = fn
+	}
+	return merged
+}
+
+// Title converts s to title case. For example "sonarr" -> "Sonarr".
+func Title(s string) string {
+	return cases.Title(language.English).String(s)
+}
+
+// TrimPrefix removes prefix from s if present.
+func TrimPrefix(prefix, s string) string {
+	return strings.TrimPrefix(s, prefix)
+}
+
+// TrimSuffix removes suffix from s if present.
+func TrimSuffix(suffix, s string) string {
+	return strings.TrimSuffix(s, suffix)
+}
+
+// Replace replaces all occurrences of old with new in s.
+func Replace(old, new, s string) string {
+	return strings.ReplaceAll(s, old, new)
+}
+
+// Contains returns true if s contains substr.
+func Contains(substr, s string) bool {
+	return strings.Contains(s, substr)
+}
+
+// HasPrefix returns true if s starts with prefix.
+func HasPrefix(prefix, s string) bool {
+	return strings.HasPrefix(s, prefix)
+}
+
+// HasSuffix returns true if s ends with suffix.
+func HasSuffix(suffix, s string) bool {
+	return strings.HasSuffix(s, suffix)
+}
+
+// Indent adds n spaces of indentation to each non-empty line.
+func Indent(n int, s string) string {
+	prefix := strings.Repeat(" ", n)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Plural returns singular when count is 1 and plural otherwise.
+// For example {{ plural "role" "roles" 3 }} -> "roles".
+func Plural(singular, plural string, count int) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
+
+// Split splits s around each instance of sep.
+func Split(sep, s string) []string {
+	return strings.Split(s, sep)
+}
+
+// Join concatenates items separated by sep.
+func Join(sep string, items []string) string {
+	return strings.Join(items, sep)
+}
+
+// SortAlpha returns a case-insensitively sorted copy of items.
+func SortAlpha(items []string) []string {
+	sorted := make([]string, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+	})
+	return sorted
+}
+
+// Uniq returns items with duplicates removed, keeping the first occurrence.
+func Uniq(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	return result
+}
+
+// Keys returns the sorted keys of a map with string keys.
+// This allows ranging over maps such as RoleVarLookups in a stable order.
+func Keys(m any) ([]string, error) {
+	value := reflect.ValueOf(m)
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("keys: expected map with string keys, got %T", m)
+	}
+
+	keys := make([]string, 0, value.Len())
+	for _, key := range value.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// EscapeMarkdown escapes characters with special meaning in inline markdown.
+func EscapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// EscapeTableCell makes s safe for use inside a markdown table cell.
+// Pipes are escaped and newlines are converted to <br>.
+func EscapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "<br>")
+}
+
+// CodeSpan wraps s in an inline code span, using a backtick fence longer
+// than any backtick run inside s.
+func CodeSpan(s string) string {
+	longest, current := 0, 0
+	for _, ch := range s {
+		if ch == '`' {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		return fence + " " + s + " " + fence
+	}
+	return fence + s + fence
+}
+
+// Slugify converts a heading to its anchor id the same way MkDocs does
+// (Python-Markdown's toc extension): unicode is decomposed and reduced to
+// ASCII, punctuation is dropped, and whitespace/hyphen runs become "-".
+// For example "Docker+ Options" -> "docker-options".
+func Slugify(s string) string {
+	var ascii strings.Builder
+	for _, r := range norm.NFKD.String(s) {
+		if r <= unicode.MaxASCII {
+			ascii.WriteRune(r)
+		}
+	}
+
+	slug := slugInvalidRe.ReplaceAllString(ascii.String(), "")
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	return slugSeparatorRe.ReplaceAllString(slug, "-")
+}
+
+// Admonition renders a MkDocs Material admonition block.
+// The kind is normalized with AdmonitionType and the body is indented by four spaces.
+// For example {{ "Back up first." | admonition "warn" "Heads up" }}.
+func Admonition(kind, title, body string) string {
+	var builder strings.Builder
+	builder.WriteString("!!! ")
+	builder.WriteString(AdmonitionType(kind))
+	if title != "" {
+		builder.WriteString(" ")
+		builder.WriteString(YAMLQuote(title))
+	}
+	builder.WriteString("\n\n")
+	builder.WriteString(Indent(4, strings.TrimRight(body, "\n")))
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// AdmonitionType normalizes an admonition qualifier to its canonical
+// MkDocs Material type, e.g. "warn" -> "warning", "error" -> "danger".
+// Unknown qualifiers fall back to "note".
+func AdmonitionType(kind string) string {
+	if canonical, ok := admonitionAliases[strings.ToLower(strings.TrimSpace(kind))]; ok {
+		return canonical
+	}
+	return "note"
+}
+
+// YAMLQuote returns s as a double-quoted YAML scalar with special characters escaped.
+func YAMLQuote(s string) string {
+	var builder strings.Builder
+	builder.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '\\':
+			builder.WriteString(`\\`)
+		case '"':
+			builder.WriteString(`\"`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&builder, `\x%02x`, r)
+			} else {
+				builder.WriteRune(r)
+			}
+		}
+	}
+	builder.WriteByte('"')
+	return builder.String()
+}
@@ -0,0 +1,245 @@
+package funcs
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"text/template"
+)
+
+func TestTitle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"sonarr", "Sonarr"},
+		{"media server", "Media Server"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Title(tt.input); got != tt.expected {
+			t.Errorf("Title(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestStringHelpers(t *testing.T) {
+	if got := TrimPrefix("sandbox-", "sandbox-app"); got != "app" {
+		t.Errorf("TrimPrefix = %q, want %q", got, "app")
+	}
+	if got := TrimSuffix(".md", "plex.md"); got != "plex" {
+		t.Errorf("TrimSuffix = %q, want %q", got, "plex")
+	}
+	if got := Replace("{role}", "plex", "{role}_name"); got != "plex_name" {
+		t.Errorf("Replace = %q, want %q", got, "plex_name")
+	}
+	if !Contains("_web_", "plex_role_web_subdomain") {
+		t.Error("Contains should match substring")
+	}
+	if !HasPrefix("plex_", "plex_name") || HasPrefix("sonarr_", "plex_name") {
+		t.Error("HasPrefix returned wrong result")
+	}
+	if !HasSuffix("_name", "plex_name") || HasSuffix("_port", "plex_name") {
+		t.Error("HasSuffix returned wrong result")
+	}
+}
+
+func TestIndent(t *testing.T) {
+	got := Indent(4, "line one\n\nline two")
+	expected := "    line one\n\n    line two"
+	if got != expected {
+		t.Errorf("Indent = %q, want %q", got, expected)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	if got := Plural("role", "roles", 1); got != "role" {
+		t.Errorf("Plural(1) = %q, want %q", got, "role")
+	}
+	if got := Plural("role", "roles", 0); got != "roles" {
+		t.Errorf("Plural(0) = %q, want %q", got, "roles")
+	}
+}
+
+func TestSplitJoin(t *testing.T) {
+	parts := Split(" > ", "Media Apps > Server")
+	if !reflect.DeepEqual(parts, []string{"Media Apps", "Server"}) {
+		t.Errorf("Split = %v", parts)
+	}
+	if got := Join(", ", []string{"a", "b", "c"}); got != "a, b, c" {
+		t.Errorf("Join = %q, want %q", got, "a, b, c")
+	}
+}
+
+func TestSortAlpha(t *testing.T) {
+	input := []string{"sonarr", "Bazarr", "plex"}
+	got := SortAlpha(input)
+	expected := []string{"Bazarr", "plex", "sonarr"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("SortAlpha = %v, want %v", got, expected)
+	}
+	if input[0] != "sonarr" {
+		t.Error("SortAlpha should not modify its input")
+	}
+}
+
+func TestUniq(t *testing.T) {
+	got := Uniq([]string{"a", "b", "a", "c", "b"})
+	expected := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Uniq = %v, want %v", got, expected)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	got, err := Keys(map[string]int{"b": 1, "a": 2, "c": 3})
+	if err != nil {
+		t.Fatalf("Keys returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Keys = %v", got)
+	}
+
+	if _, err := Keys([]string{"a"}); err == nil {
+		t.Error("Keys should reject non-map values")
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	got := EscapeMarkdown("use *only* [this] | `that` <x>_y")
+	expected := "use \\*only\\* \\[this\\] \\| \\`that\\` \\<x\\>\\_y"
+	if got != expected {
+		t.Errorf("EscapeMarkdown = %q, want %q", got, expected)
+	}
+}
+
+func TestEscapeTableCell(t *testing.T) {
+	got := EscapeTableCell("a | b\nc\r\nd\n")
+	expected := "a \\| b<br>c<br>d"
+	if got != expected {
+		t.Errorf("EscapeTableCell = %q, want %q", got, expected)
+	}
+}
+
+func TestCodeSpan(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plex_name", "`plex_name`"},
+		{"a `b` c", "``a `b` c``"},
+		{"`edge", "`` `edge ``"},
+	}
+
+	for _, tt := range tests {
+		if got := CodeSpan(tt.input); got != tt.expected {
+			t.Errorf("CodeSpan(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Docker+ Options", "docker-options"},
+		{"Global Override Options", "global-override-options"},
+		{"  Café & Crème  ", "cafe-creme"},
+		{"role_var lookups", "role_var-lookups"},
+		{"Multi--Dash -- Heading", "multi-dash-heading"},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.input); got != tt.expected {
+			t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestAdmonition(t *testing.T) {
+	got := Admonition("warn", "Heads up", "Back up first.\nThen upgrade.\n")
+	expected := "!!! warning \"Heads up\"\n\n    Back up first.\n    Then upgrade.\n"
+	if got != expected {
+		t.Errorf("Admonition = %q, want %q", got, expected)
+	}
+
+	got = Admonition("tip", "", "Body")
+	expected = "!!! tip\n\n    Body\n"
+	if got != expected {
+		t.Errorf("Admonition without title = %q, want %q", got, expected)
+	}
+}
+
+func TestAdmonitionType(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"warning", "warning"},
+		{"Caution", "warning"},
+		{"error", "danger"},
+		{"hint", "tip"},
+		{"tldr", "abstract"},
+		{"unknown", "note"},
+		{"", "note"},
+	}
+
+	for _, tt := range tests {
+		if got := AdmonitionType(tt.input); got != tt.expected {
+			t.Errorf("AdmonitionType(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestYAMLQuote(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"plex", `"plex"`},
+		{`say "hi"`, `"say \"hi\""`},
+		{`C:\path`, `"C:\\path"`},
+		{"a\nb\tc", `"a\nb\tc"`},
+		{"", `""`},
+	}
+
+	for _, tt := range tests {
+		if got := YAMLQuote(tt.input); got != tt.expected {
+			t.Errorf("YAMLQuote(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(template.FuncMap{"title": func(s string) string { return "custom" }})
+	if _, ok := merged["slugify"]; !ok {
+		t.Error("Merge should keep shared functions")
+	}
+
+	tmpl := template.Must(template.New("t").Funcs(merged).Parse(`{{title "x"}}`))
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, nil); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	if buf.String() != "custom" {
+		t.Errorf("Merge should let extra functions override shared ones, got %q", buf.String())
+	}
+}
+
+func TestMapInTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("t").Funcs(Map()).Parse(
+		`{{.Cats | sortAlpha | join ", "}}|{{.Name | slugify}}|{{.Name | yamlQuote}}`))
+
+	var buf bytes.Buffer
+	data := map[string]any{"Cats": []string{"b", "a"}, "Name": "Media Server"}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	expected := `a, b|media-server|"Media Server"`
+	if buf.String() != expected {
+		t.Errorf("template output = %q, want %q", buf.String(), expected)
+	}
+}
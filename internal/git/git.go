@@ -0,0 +1,141 @@
+// Package git runs the git commands used to propose documentation changes.
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"net/mail"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultAuthor is the commit identity used when git has none configured,
+// as on fresh CI runners.
+const DefaultAuthor = "docs-automation <docs-automation@users.noreply.github.com>"
+
+// Repo is a git working tree.
+type Repo struct {
+	Dir    string // Top-level directory of the working tree
+	Author string // "Name <email>" of commits; the git identity, else DefaultAuthor, when empty
+}
+
+// Open returns the repository whose working tree contains dir.
+func Open(dir string) (*Repo, error) {
+	repo := &Repo{Dir: dir}
+	top, err := repo.run(nil, "", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	repo.Dir = top
+	return repo, nil
+}
+
+// run runs git in the working tree with extra environment variables and
+// standard input, and returns its trimmed standard output.
+func (r *Repo) run(env []string, stdin string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.Dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = strings.NewReader(stdin)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// CurrentBranch returns the checked out branch.
+func (r *Repo) CurrentBranch() (string, error) {
+	branch, err := r.run(nil, "", "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", fmt.Errorf("HEAD is detached")
+	}
+	return branch, nil
+}
+
+// CommitFiles creates a commit on top of parent holding the working tree
+// content of files, and returns its hash. A temporary index is used, so the
+// checkout, the index and all branches are left as they are. It returns ""
+// when the files do not differ from parent.
+func (r *Repo) CommitFiles(parent string, files []string, message string) (string, error) {
+	index, err := os.CreateTemp("", "sb-docs-index-*")
+	if err != nil {
+		return "", err
+	}
+	index.Close()
+	defer os.Remove(index.Name())
+
+	env := []string{"GIT_INDEX_FILE=" + index.Name()}
+	identity, err := r.identity()
+	if err != nil {
+		return "", err
+	}
+	env = append(env, identity...)
+
+	if _, err := r.run(env, "", "read-tree", parent); err != nil {
+		return "", err
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		if rel, err := filepath.Rel(r.Dir, file); err == nil && filepath.IsAbs(file) {
+			file = rel
+		}
+		paths = append(paths, filepath.ToSlash(file))
+	}
+	if _, err := r.run(env, "", append([]string{"add", "--"}, paths...)...); err != nil {
+		return "", err
+	}
+
+	tree, err := r.run(env, "", "write-tree")
+	if err != nil {
+		return "", err
+	}
+	parentTree, err := r.run(nil, "", "rev-parse", parent+"^{tree}")
+	if err != nil {
+		return "", err
+	}
+	if tree == parentTree {
+		return "", nil
+	}
+
+	return r.run(env, message, "commit-tree", tree, "-p", parent, "-F", "-")
+}
+
+// identity returns the author and committer environment for commits: Author
+// when set, nothing when git has an identity configured, else DefaultAuthor.
+func (r *Repo) identity() ([]string, error) {
+	author := r.Author
+	if author == "" {
+		if email, _ := r.run(nil, "", "config", "user.email"); email != "" {
+			return nil, nil
+		}
+		author = DefaultAuthor
+	}
+
+	address, err := mail.ParseAddress(author)
+	if err != nil {
+		return nil, fmt.Errorf("invalid author %q: %w", author, err)
+	}
+	return []string{
+		"GIT_AUTHOR_NAME=" + address.Name,
+		"GIT_AUTHOR_EMAIL=" + address.Address,
+		"GIT_COMMITTER_NAME=" + address.Name,
+		"GIT_COMMITTER_EMAIL=" + address.Address,
+	}, nil
+}
+
+// Push force-pushes a revision to a branch of a remote, replacing the branch.
+func (r *Repo) Push(remote, rev, branch string) error {
+	_, err := r.run(nil, "", "push", "--force", remote, rev+":refs/heads/"+branch)
+	return err
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitCmd runs git in dir and fails the test on error.
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestCommitFilesAndPush(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	root := t.TempDir()
+	remote := filepath.Join(root, "remote.git")
+	work := filepath.Join(root, "docs")
+	gitCmd(t, root, "init", "--bare", "--initial-branch=main", remote)
+	gitCmd(t, root, "clone", remote, work)
+
+	write := func(name, content string) {
+		path := filepath.Join(work, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("docs/apps/plex.md", "old\n")
+	write("docs/apps/sonarr.md", "old\n")
+	gitCmd(t, work, "add", ".")
+	gitCmd(t, work, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-m", "Initial")
+	gitCmd(t, work, "push", "origin", "main")
+
+	repo, err := Open(filepath.Join(work, "docs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch, err := repo.CurrentBranch(); err != nil || branch != "main" {
+		t.Fatalf("CurrentBranch = %q, %v", branch, err)
+	}
+
+	// Unchanged files make no commit
+	if sha, err := repo.CommitFiles("HEAD", []string{filepath.Join(work, "docs/apps/plex.md")}, "Nothing"); err != nil || sha != "" {
+		t.Fatalf("CommitFiles without changes = %q, %v", sha, err)
+	}
+
+	// Only the given files are committed, leaving the checkout alone
+	write("docs/apps/plex.md", "new\n")
+	write("docs/apps/sonarr.md", "local edit\n")
+	sha, err := repo.CommitFiles("HEAD", []string{filepath.Join(work, "docs/apps/plex.md")}, "Update plex documentation\n")
+	if err != nil || sha == "" {
+		t.Fatalf("CommitFiles = %q, %v", sha, err)
+	}
+	if err := repo.Push("origin", sha, "sb-docs/update"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := gitCmd(t, remote, "show", "sb-docs/update:docs/apps/plex.md"); got != "new" {
+		t.Errorf("pushed plex.md = %q", got)
+	}
+	if got := gitCmd(t, remote, "show", "sb-docs/update:docs/apps/sonarr.md"); got != "old" {
+		t.Errorf("pushed sonarr.md = %q, want the committed version", got)
+	}
+	if got := gitCmd(t, remote, "log", "-1", "--format=%an <%ae>|%s", "sb-docs/update"); got != DefaultAuthor+"|Update plex documentation" {
+		t.Errorf("pushed commit = %q", got)
+	}
+	// The leading space of the first status line is trimmed
+	if got := gitCmd(t, work, "status", "--porcelain"); got != "M docs/apps/plex.md\n M docs/apps/sonarr.md" {
+		t.Errorf("working tree status = %q", got)
+	}
+	if got := gitCmd(t, work, "rev-parse", "HEAD"); got != gitCmd(t, remote, "rev-parse", "main") {
+		t.Error("HEAD moved")
+	}
+}
@@ -0,0 +1,332 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Annotation rule IDs, one per kind of problem.
+const (
+	RuleFrontmatter             = "frontmatter"
+	RuleMarkers                 = "markers"
+	RuleParse                   = "parse"
+	RuleUpdate                  = "update"
+	RuleMissingDocs             = "missing-docs"
+	RuleMissingSections         = "missing-sections"
+	RuleMissingOverviewSections = "missing-overview-sections"
+	RuleOrphanedDocs            = "orphaned-docs"
+	RuleInvalidInstallTags      = "invalid-install-tags"
+	RuleBrokenLinks             = "broken-links"
+)
+
+// ruleDescriptions describes the rules in SARIF logs.
+var ruleDescriptions = map[string]string{
+	RuleFrontmatter:             "Invalid frontmatter",
+	RuleMarkers:                 "Unmatched managed section marker",
+	RuleParse:                   "Role defaults, playbook or inventory could not be parsed",
+	RuleUpdate:                  "Documentation could not be updated",
+	RuleMissingDocs:             "Role without documentation",
+	RuleMissingSections:         "Doc without a managed variables section",
+	RuleMissingOverviewSections: "Doc without a managed overview section",
+	RuleOrphanedDocs:            "Doc without a corresponding role",
+	RuleInvalidInstallTags:      "Install command with an unknown tag",
+	RuleBrokenLinks:             "Broken external link",
+}
+
+// Annotation is a problem at a location in a file.
+type Annotation struct {
+	Rule    string // One of the Rule constants
+	File    string // Path of the file, as found on disk
+	Line    int    // 1-based line, 0 when unknown
+	Message string
+}
+
+// Annotator collects annotations. In GitHub Actions each annotation is
+// also printed as an ::error workflow command as it is added, which shows
+// it inline on pull request diffs.
+type Annotator struct {
+	Out  io.Writer // Where workflow commands are printed; nil prints none
+	Root string    // Directory file paths are made relative to
+
+	annotations []Annotation
+}
+
+// NewAnnotator returns an annotator that prints workflow commands to stdout
+// when running in GitHub Actions. Paths are made relative to
+// GITHUB_WORKSPACE, or the working directory outside GitHub Actions.
+func NewAnnotator() *Annotator {
+	a := &Annotator{Root: os.Getenv("GITHUB_WORKSPACE")}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		a.Out = os.Stdout
+	}
+	if a.Root == "" {
+		a.Root, _ = os.Getwd()
+	}
+	return a
+}
+
+// Add records an annotation and prints its workflow command.
+func (a *Annotator) Add(annotation Annotation) {
+	annotation.File = a.relPath(annotation.File)
+	a.annotations = append(a.annotations, annotation)
+	if a.Out != nil {
+		fmt.Fprintln(a.Out, WorkflowCommand(annotation))
+	}
+}
+
+// Annotations returns the annotations added so far.
+func (a *Annotator) Annotations() []Annotation {
+	return a.annotations
+}
+
+// relPath returns path relative to the root, with forward slashes. Paths
+// outside the root are returned as they are.
+func (a *Annotator) relPath(path string) string {
+	if path == "" || a.Root == "" {
+		return filepath.ToSlash(path)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	rel, err := filepath.Rel(a.Root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// WorkflowCommand formats an annotation as an ::error workflow command.
+func WorkflowCommand(a Annotation) string {
+	var props []string
+	if a.File != "" {
+		props = append(props, "file="+escapeProperty(a.File))
+		if a.Line > 0 {
+			props = append(props, "line="+strconv.Itoa(a.Line))
+		}
+	}
+	if title, ok := ruleDescriptions[a.Rule]; ok {
+		props = append(props, "title="+escapeProperty(title))
+	}
+
+	command := "::error"
+	if len(props) > 0 {
+		command += " " + strings.Join(props, ",")
+	}
+	return command + "::" + escapeData(a.Message)
+}
+
+// escapeData escapes the message of a workflow command.
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeProperty escapes a property value of a workflow command.
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// locationRe matches the "path:line: " prefix of check result entries.
+var locationRe = regexp.MustCompile(`^(.+?):(\d+): (.*)$`)
+
+// splitLocation splits a "path:line: rest" entry. The line is 0 when the
+// entry has no line.
+func splitLocation(entry string) (path string, line int, rest string) {
+	if m := locationRe.FindStringSubmatch(entry); m != nil {
+		line, _ = strconv.Atoi(m[2])
+		return m[1], line, m[3]
+	}
+	if path, rest, ok := strings.Cut(entry, ": "); ok {
+		return path, 0, rest
+	}
+	return entry, 0, ""
+}
+
+// errorLineRe matches the line number in YAML errors.
+var errorLineRe = regexp.MustCompile(`\bline (\d+)\b`)
+
+// ErrorLine returns the line number mentioned in an error message such as
+// "yaml: line 12: mapping values are not allowed", or 0.
+func ErrorLine(message string) int {
+	if m := errorLineRe.FindStringSubmatch(message); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line
+	}
+	return 0
+}
+
+// CheckAnnotations converts coverage check results to annotations. Doc
+// paths are joined to docsRoot. Missing docs and orphaned docs are listed
+// by name, so locate returns the file to annotate for them, given the item
+// kind (KindMissingDocs or KindOrphanedDocs) and name; it may be nil when
+// there are none.
+func CheckAnnotations(result *CheckResult, docsRoot string, locate func(kind, name string) string) []Annotation {
+	var annotations []Annotation
+
+	for _, role := range result.MissingDocs {
+		annotations = append(annotations, Annotation{
+			Rule:    RuleMissingDocs,
+			File:    locate(KindMissingDocs, role),
+			Message: fmt.Sprintf("Role %s has no documentation page", role),
+		})
+	}
+	for _, doc := range result.MissingSections {
+		annotations = append(annotations, Annotation{
+			Rule:    RuleMissingSections,
+			File:    filepath.Join(docsRoot, doc),
+			Line:    1,
+			Message: "Doc has no managed variables section",
+		})
+	}
+	for _, doc := range result.MissingOverviewSections {
+		annotations = append(annotations, Annotation{
+			Rule:    RuleMissingOverviewSections,
+			File:    filepath.Join(docsRoot, doc),
+			Line:    1,
+			Message: "Doc has no managed overview section",
+		})
+	}
+	for _, name := range result.OrphanedDocs {
+		annotations = append(annotations, Annotation{
+			Rule:    RuleOrphanedDocs,
+			File:    locate(KindOrphanedDocs, name),
+			Line:    1,
+			Message: fmt.Sprintf("Doc %s has no corresponding Saltbox or Sandbox role", name),
+		})
+	}
+	for _, entry := range result.InvalidInstallTags {
+		path, line, tag := splitLocation(entry)
+		annotations = append(annotations, Annotation{
+			Rule:    RuleInvalidInstallTags,
+			File:    filepath.Join(docsRoot, path),
+			Line:    line,
+			Message: fmt.Sprintf("Install tag %s is not defined in the playbook", tag),
+		})
+	}
+	for _, entry := range result.BrokenLinks {
+		path, line, link := splitLocation(entry)
+		annotations = append(annotations, Annotation{
+			Rule:    RuleBrokenLinks,
+			File:    filepath.Join(docsRoot, path),
+			Line:    line,
+			Message: "Broken link: " + link,
+		})
+	}
+
+	return annotations
+}
+
+// sarifLog is the subset of SARIF 2.1.0 written by WriteSARIF.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSARIF writes the annotations as a SARIF 2.1.0 log, e.g. for
+// upload to GitHub code scanning. version is the tool version.
+func (a *Annotator) WriteSARIF(path, version string) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "sb-docs",
+			Version:        version,
+			InformationURI: "https://github.com/saltyorg/docs-automation",
+		}},
+		Results: []sarifResult{},
+	}
+
+	seen := make(map[string]bool)
+	for _, annotation := range a.annotations {
+		if !seen[annotation.Rule] {
+			seen[annotation.Rule] = true
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               annotation.Rule,
+				ShortDescription: sarifMessage{Text: ruleDescriptions[annotation.Rule]},
+			})
+		}
+
+		result := sarifResult{
+			RuleID:  annotation.Rule,
+			Level:   "error",
+			Message: sarifMessage{Text: annotation.Message},
+		}
+		if annotation.File != "" {
+			location := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: annotation.File},
+			}}
+			if annotation.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartLine: annotation.Line}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+		run.Results = append(run.Results, result)
+	}
+	if run.Tool.Driver.Rules == nil {
+		run.Tool.Driver.Rules = []sarifRule{}
+	}
+
+	data, err := json.MarshalIndent(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,158 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWorkflowCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation Annotation
+		want       string
+	}{
+		{
+			name:       "file and line",
+			annotation: Annotation{Rule: RuleMarkers, File: "docs/apps/plex.md", Line: 12, Message: `missing END marker for "X"`},
+			want:       `::error file=docs/apps/plex.md,line=12,title=Unmatched managed section marker::missing END marker for "X"`,
+		},
+		{
+			name:       "no line",
+			annotation: Annotation{Rule: RuleMissingDocs, File: "roles/plex/defaults/main.yml", Message: "Role plex has no documentation page"},
+			want:       "::error file=roles/plex/defaults/main.yml,title=Role without documentation::Role plex has no documentation page",
+		},
+		{
+			name:       "escaping",
+			annotation: Annotation{Rule: "unknown", File: "a,b:c.md", Line: 1, Message: "100% broken\nsecond line"},
+			want:       "::error file=a%2Cb%3Ac.md,line=1::100%25 broken%0Asecond line",
+		},
+		{
+			name:       "no file",
+			annotation: Annotation{Message: "failed"},
+			want:       "::error::failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WorkflowCommand(tt.annotation); got != tt.want {
+				t.Errorf("WorkflowCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotatorAdd(t *testing.T) {
+	root := t.TempDir()
+	var out bytes.Buffer
+	a := &Annotator{Out: &out, Root: root}
+
+	a.Add(Annotation{Rule: RuleFrontmatter, File: filepath.Join(root, "docs", "plex.md"), Line: 3, Message: "bad"})
+	a.Add(Annotation{Rule: RuleFrontmatter, File: "/elsewhere/plex.md", Message: "bad"})
+
+	if got := a.Annotations()[0].File; got != "docs/plex.md" {
+		t.Errorf("file inside root = %q, want docs/plex.md", got)
+	}
+	if got := a.Annotations()[1].File; got != "/elsewhere/plex.md" {
+		t.Errorf("file outside root = %q, want it unchanged", got)
+	}
+	want := "::error file=docs/plex.md,line=3,title=Invalid frontmatter::bad\n" +
+		"::error file=/elsewhere/plex.md,title=Invalid frontmatter::bad\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestErrorLine(t *testing.T) {
+	tests := map[string]int{
+		"parsing frontmatter YAML: yaml: line 4: mapping values are not allowed in this context": 4,
+		"yaml: unmarshal errors:\n  line 12: cannot unmarshal !!seq into string":                 12,
+		"unclosed frontmatter: missing closing ---":                                              0,
+		"timeline 5 is not a line number":                                                        0,
+	}
+	for message, want := range tests {
+		if got := ErrorLine(message); got != want {
+			t.Errorf("ErrorLine(%q) = %d, want %d", message, got, want)
+		}
+	}
+}
+
+func TestCheckAnnotations(t *testing.T) {
+	result := &CheckResult{
+		MissingDocs:        []string{"sandbox/foo"},
+		MissingSections:    []string{"docs/apps/plex.md"},
+		OrphanedDocs:       []string{"old"},
+		InvalidInstallTags: []string{"docs/apps/plex.md:14: plexx"},
+		BrokenLinks:        []string{"docs/apps/plex.md:20: https://example.com (404 Not Found)", "docs/apps/x.md: https://x (timeout)"},
+	}
+	locate := func(kind, name string) string {
+		if kind == KindOrphanedDocs {
+			return "repo/docs/apps/" + name + ".md"
+		}
+		return "roles/" + name + "/defaults/main.yml"
+	}
+
+	got := CheckAnnotations(result, "repo", locate)
+	want := []Annotation{
+		{Rule: RuleMissingDocs, File: "roles/sandbox/foo/defaults/main.yml", Message: "Role sandbox/foo has no documentation page"},
+		{Rule: RuleMissingSections, File: "repo/docs/apps/plex.md", Line: 1, Message: "Doc has no managed variables section"},
+		{Rule: RuleOrphanedDocs, File: "repo/docs/apps/old.md", Line: 1, Message: "Doc old has no corresponding Saltbox or Sandbox role"},
+		{Rule: RuleInvalidInstallTags, File: "repo/docs/apps/plex.md", Line: 14, Message: "Install tag plexx is not defined in the playbook"},
+		{Rule: RuleBrokenLinks, File: "repo/docs/apps/plex.md", Line: 20, Message: "Broken link: https://example.com (404 Not Found)"},
+		{Rule: RuleBrokenLinks, File: "repo/docs/apps/x.md", Message: "Broken link: https://x (timeout)"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CheckAnnotations() =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	a := &Annotator{}
+	a.Add(Annotation{Rule: RuleMarkers, File: "docs/plex.md", Line: 7, Message: "missing END marker"})
+	a.Add(Annotation{Rule: RuleMarkers, File: "docs/emby.md", Message: "missing BEGIN marker"})
+	a.Add(Annotation{Rule: RuleParse, File: "roles/plex/defaults/main.yml", Line: 2, Message: "parsing failed"})
+
+	path := filepath.Join(t.TempDir(), "sb-docs.sarif")
+	if err := a.WriteSARIF(path, "1.2.3"); err != nil {
+		t.Fatalf("WriteSARIF() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("version %q with %d runs, want 2.1.0 with 1 run", log.Version, len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "sb-docs" || run.Tool.Driver.Version != "1.2.3" {
+		t.Errorf("driver = %+v", run.Tool.Driver)
+	}
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != RuleMarkers || run.Tool.Driver.Rules[1].ID != RuleParse {
+		t.Errorf("rules = %+v, want markers and parse once each", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 3 {
+		t.Fatalf("got %d results, want 3", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" || first.Message.Text != "missing END marker" {
+		t.Errorf("first result = %+v", first)
+	}
+	location := first.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "docs/plex.md" || location.Region == nil || location.Region.StartLine != 7 {
+		t.Errorf("first location = %+v", location)
+	}
+	if region := run.Results[1].Locations[0].PhysicalLocation.Region; region != nil {
+		t.Errorf("result without line has region %+v", region)
+	}
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/saltyorg/docs-automation/internal/runtime"
+)
+
+// doJSON sends a request with a JSON body (when in is non-nil) and decodes
+// a JSON response into out (when non-nil). header holds the authentication
+// and accept headers of the API. Non-2xx responses become errors carrying
+// the API's "message" when there is one. A nil client uses a 30s timeout.
+func doJSON(client *http.Client, header http.Header, method, endpoint string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("User-Agent", "sb-docs/"+runtime.Version)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message any `json:"message"` // GitLab may return an object
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != nil && apiErr.Message != "" {
+			return fmt.Errorf("%s %s: %v (%d)", method, endpoint, apiErr.Message, resp.StatusCode)
+		}
+		return fmt.Errorf("%s %s: %s", method, endpoint, resp.Status)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("parsing %s response: %w", endpoint, err)
+	}
+	return nil
+}
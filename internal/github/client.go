@@ -0,0 +1,88 @@
+package github
+
+import (
+	"fmt"
+	"os"
+)
+
+// Issue states as reported by IssueClient implementations.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+)
+
+// Issue backends for NewIssueClient.
+const (
+	BackendAuto = "auto" // API when a token is available, gh otherwise
+	BackendAPI  = "api"  // GitHub REST/GraphQL API over net/http
+	BackendGH   = "gh"   // gh CLI
+)
+
+// DefaultAPIURL is the REST API base URL of github.com.
+const DefaultAPIURL = "https://api.github.com"
+
+// Issue is a repository issue.
+type Issue struct {
+	Number int
+	Title  string
+	Body   string
+	State  string // StateOpen or StateClosed
+	NodeID string // GraphQL node ID, used for pinning
+}
+
+// Comment is an issue or pull request comment.
+type Comment struct {
+	ID   int64
+	Body string
+}
+
+// IssueClient manages the issues of one repository.
+type IssueClient interface {
+	// FindIssue returns the most recently created issue with the label in
+	// any state, or nil if there is none.
+	FindIssue(label string) (*Issue, error)
+	// ListIssues returns all open issues with the label.
+	ListIssues(label string) ([]*Issue, error)
+	CreateIssue(title, body, label string) (*Issue, error)
+	UpdateIssue(number int, title, body string) error
+	CloseIssue(number int) error
+	ReopenIssue(number int) error
+	AddComment(number int, body string) error
+	PinIssue(issue *Issue) error
+	UnpinIssue(issue *Issue) error
+}
+
+// NewIssueClient returns the issue client of a backend for repo ("owner/repo").
+// The API backend authenticates with GITHUB_TOKEN (or GH_TOKEN) against
+// apiURL, which defaults to GITHUB_API_URL and then to github.com.
+func NewIssueClient(backend, repo, apiURL string) (IssueClient, error) {
+	token := Token()
+	if apiURL == "" {
+		apiURL = os.Getenv("GITHUB_API_URL")
+	}
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	switch backend {
+	case BackendAPI:
+		if token == "" {
+			return nil, fmt.Errorf("GITHUB_TOKEN is not set")
+		}
+		return NewRESTClient(repo, token, apiURL), nil
+	case BackendGH:
+		return NewGHClient(repo)
+	case BackendAuto, "":
+		if token != "" {
+			return NewRESTClient(repo, token, apiURL), nil
+		}
+		return NewGHClient(repo)
+	default:
+		return nil, fmt.Errorf("unknown issue backend %q (expected auto, api or gh)", backend)
+	}
+}
+
+// Token returns the GitHub token from GITHUB_TOKEN or GH_TOKEN.
+func Token() string {
+	return firstEnv("GITHUB_TOKEN", "GH_TOKEN")
+}
@@ -0,0 +1,236 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Supported forges.
+const (
+	ForgeGitHub = "github"
+	ForgeGitLab = "gitlab"
+	ForgeGitea  = "gitea"
+)
+
+// Forge is a code hosting platform: its issue tracker, pull requests and
+// source links.
+type Forge interface {
+	IssueClient
+	PullRequestClient
+
+	// SourceURL returns the web URL of a repository file at a branch.
+	SourceURL(branch, path string) string
+}
+
+// CIEnv describes the CI run sb-docs is running in, detected from the
+// environment variables of GitHub Actions, Gitea Actions or GitLab CI.
+type CIEnv struct {
+	Forge     string // ForgeGitHub, ForgeGitLab or ForgeGitea; "" outside CI
+	ServerURL string // Web URL of the forge, e.g. https://github.com
+	APIURL    string // API base URL when the CI provides it
+	Repo      string // "owner/repo" (GitLab: project path)
+	Branch    string // Source branch of a pull/merge request, else the ref name
+	RunURL    string // URL of the workflow run or pipeline
+}
+
+// DetectCI reads the CI environment. Outside CI the GITHUB_* variables are
+// still honored, and the branch defaults to "main".
+func DetectCI() CIEnv {
+	var env CIEnv
+	switch {
+	case os.Getenv("GITLAB_CI") == "true":
+		env = CIEnv{
+			Forge:     ForgeGitLab,
+			ServerURL: os.Getenv("CI_SERVER_URL"),
+			APIURL:    os.Getenv("CI_API_V4_URL"),
+			Repo:      os.Getenv("CI_PROJECT_PATH"),
+			Branch:    firstEnv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_COMMIT_REF_NAME"),
+			RunURL:    os.Getenv("CI_PIPELINE_URL"),
+		}
+
+	case os.Getenv("GITEA_ACTIONS") == "true":
+		// Gitea Actions also sets GITHUB_ACTIONS and the GITHUB_* variables
+		env = CIEnv{
+			Forge:     ForgeGitea,
+			ServerURL: os.Getenv("GITHUB_SERVER_URL"),
+			Repo:      os.Getenv("GITHUB_REPOSITORY"),
+			Branch:    firstEnv("GITHUB_HEAD_REF", "GITHUB_REF_NAME"),
+		}
+		if env.ServerURL != "" {
+			env.APIURL = strings.TrimSuffix(env.ServerURL, "/") + "/api/v1"
+		}
+		if runNumber := os.Getenv("GITHUB_RUN_NUMBER"); env.ServerURL != "" && env.Repo != "" && runNumber != "" {
+			env.RunURL = fmt.Sprintf("%s/%s/actions/runs/%s", env.ServerURL, env.Repo, runNumber)
+		}
+
+	default:
+		env = CIEnv{
+			ServerURL: os.Getenv("GITHUB_SERVER_URL"),
+			APIURL:    os.Getenv("GITHUB_API_URL"),
+			Repo:      os.Getenv("GITHUB_REPOSITORY"),
+			Branch:    firstEnv("GITHUB_HEAD_REF", "GITHUB_REF_NAME"),
+		}
+		if os.Getenv("GITHUB_ACTIONS") == "true" {
+			env.Forge = ForgeGitHub
+		}
+		if runID := os.Getenv("GITHUB_RUN_ID"); env.ServerURL != "" && env.Repo != "" && runID != "" {
+			env.RunURL = fmt.Sprintf("%s/%s/actions/runs/%s", env.ServerURL, env.Repo, runID)
+		}
+	}
+
+	if env.Branch == "" {
+		env.Branch = "main"
+	}
+	return env
+}
+
+// firstEnv returns the first non-empty environment variable of names.
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// ForgeOptions selects and configures a forge. Empty fields are filled from
+// the detected CI environment and the forge defaults.
+type ForgeOptions struct {
+	Type         string // ForgeGitHub, ForgeGitLab, ForgeGitea, or "" / "auto" to detect
+	ServerURL    string // Web URL of the forge
+	APIURL       string // API base URL
+	Repo         string // "owner/repo" or GitLab project path
+	IssueBackend string // GitHub only: BackendAuto, BackendAPI or BackendGH
+}
+
+// NewForge returns the forge selected by opts, detecting the type from the
+// CI environment (GitHub by default). Tokens are read from GITHUB_TOKEN or
+// GH_TOKEN (GitHub), GITLAB_TOKEN (GitLab) and GITEA_TOKEN or GITHUB_TOKEN
+// (Gitea).
+func NewForge(opts ForgeOptions) (Forge, error) {
+	kind, opts, err := resolveForge(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case ForgeGitHub:
+		client, err := NewIssueClient(opts.IssueBackend, opts.Repo, opts.APIURL)
+		if err != nil {
+			return nil, err
+		}
+		return NewGitHubForge(client, opts.ServerURL, opts.Repo), nil
+
+	case ForgeGitLab:
+		token := os.Getenv("GITLAB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITLAB_TOKEN is not set")
+		}
+		return NewGitLabClient(opts.Repo, token, opts.ServerURL, opts.APIURL), nil
+
+	default:
+		token := firstEnv("GITEA_TOKEN", "GITHUB_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("GITEA_TOKEN is not set")
+		}
+		return NewGiteaClient(opts.Repo, token, opts.ServerURL, opts.APIURL), nil
+	}
+}
+
+// NewSourceForge returns the forge selected by opts like NewForge, but
+// without credentials: only its SourceURL is usable.
+func NewSourceForge(opts ForgeOptions) (Forge, error) {
+	kind, opts, err := resolveForge(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case ForgeGitHub:
+		return NewGitHubForge(nil, opts.ServerURL, opts.Repo), nil
+	case ForgeGitLab:
+		return NewGitLabClient(opts.Repo, "", opts.ServerURL, opts.APIURL), nil
+	default:
+		return NewGiteaClient(opts.Repo, "", opts.ServerURL, opts.APIURL), nil
+	}
+}
+
+// resolveForge returns the forge type selected by opts and the options
+// completed from the CI environment and the forge defaults.
+func resolveForge(opts ForgeOptions) (string, ForgeOptions, error) {
+	env := DetectCI()
+
+	kind := opts.Type
+	if kind == "" || kind == "auto" {
+		kind = env.Forge
+	}
+	if kind == "" {
+		kind = ForgeGitHub
+	}
+
+	// CI values only apply to the forge they were detected for
+	if kind == env.Forge || env.Forge == "" {
+		if opts.ServerURL == "" {
+			opts.ServerURL = env.ServerURL
+		}
+		if opts.APIURL == "" {
+			opts.APIURL = env.APIURL
+		}
+		if opts.Repo == "" {
+			opts.Repo = env.Repo
+		}
+	}
+	if opts.Repo == "" {
+		return "", opts, fmt.Errorf("repository unknown: set forge.repo or run in CI")
+	}
+	opts.ServerURL = strings.TrimSuffix(opts.ServerURL, "/")
+
+	switch kind {
+	case ForgeGitHub:
+		if opts.ServerURL == "" {
+			opts.ServerURL = "https://github.com"
+		}
+		if opts.APIURL == "" && opts.ServerURL != "https://github.com" {
+			opts.APIURL = opts.ServerURL + "/api/v3"
+		}
+
+	case ForgeGitLab:
+		if opts.ServerURL == "" {
+			opts.ServerURL = "https://gitlab.com"
+		}
+		if opts.APIURL == "" {
+			opts.APIURL = opts.ServerURL + "/api/v4"
+		}
+
+	case ForgeGitea:
+		if opts.ServerURL == "" {
+			return "", opts, fmt.Errorf("gitea requires forge.server_url")
+		}
+		if opts.APIURL == "" {
+			opts.APIURL = opts.ServerURL + "/api/v1"
+		}
+
+	default:
+		return "", opts, fmt.Errorf("unknown forge %q (expected github, gitlab or gitea)", kind)
+	}
+	return kind, opts, nil
+}
+
+// GitHubForge is the GitHub forge, using any GitHub IssueClient backend.
+type GitHubForge struct {
+	IssueClient
+	serverURL string // Web URL, e.g. https://github.com
+	repo      string // Repository in format "owner/repo"
+}
+
+// NewGitHubForge returns a GitHub forge for repo on serverURL.
+func NewGitHubForge(client IssueClient, serverURL, repo string) *GitHubForge {
+	return &GitHubForge{IssueClient: client, serverURL: strings.TrimSuffix(serverURL, "/"), repo: repo}
+}
+
+// SourceURL returns the web URL of a file at a branch.
+func (f *GitHubForge) SourceURL(branch, path string) string {
+	return fmt.Sprintf("%s/%s/blob/%s/%s", f.serverURL, f.repo, branch, path)
+}
@@ -0,0 +1,272 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ciVars are the CI variables read by DetectCI, cleared between cases.
+var ciVars = []string{
+	"GITLAB_CI", "CI_SERVER_URL", "CI_API_V4_URL", "CI_PROJECT_PATH", "CI_MERGE_REQUEST_SOURCE_BRANCH_NAME",
+	"CI_COMMIT_REF_NAME", "CI_PIPELINE_URL", "GITEA_ACTIONS", "GITHUB_ACTIONS", "GITHUB_SERVER_URL",
+	"GITHUB_API_URL", "GITHUB_REPOSITORY", "GITHUB_HEAD_REF", "GITHUB_REF_NAME", "GITHUB_RUN_ID", "GITHUB_RUN_NUMBER",
+}
+
+func TestDetectCI(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected CIEnv
+	}{
+		{
+			name:     "outside CI",
+			env:      map[string]string{},
+			expected: CIEnv{Branch: "main"},
+		},
+		{
+			name: "github actions pull request",
+			env: map[string]string{
+				"GITHUB_ACTIONS": "true", "GITHUB_SERVER_URL": "https://github.com", "GITHUB_API_URL": "https://api.github.com",
+				"GITHUB_REPOSITORY": "saltyorg/docs", "GITHUB_HEAD_REF": "feature", "GITHUB_REF_NAME": "12/merge",
+				"GITHUB_RUN_ID": "42",
+			},
+			expected: CIEnv{
+				Forge: ForgeGitHub, ServerURL: "https://github.com", APIURL: "https://api.github.com",
+				Repo: "saltyorg/docs", Branch: "feature", RunURL: "https://github.com/saltyorg/docs/actions/runs/42",
+			},
+		},
+		{
+			name: "gitea actions",
+			env: map[string]string{
+				"GITEA_ACTIONS": "true", "GITHUB_ACTIONS": "true", "GITHUB_SERVER_URL": "https://git.example.com",
+				"GITHUB_REPOSITORY": "saltyorg/docs", "GITHUB_REF_NAME": "master", "GITHUB_RUN_ID": "1234",
+				"GITHUB_RUN_NUMBER": "7",
+			},
+			expected: CIEnv{
+				Forge: ForgeGitea, ServerURL: "https://git.example.com", APIURL: "https://git.example.com/api/v1",
+				Repo: "saltyorg/docs", Branch: "master", RunURL: "https://git.example.com/saltyorg/docs/actions/runs/7",
+			},
+		},
+		{
+			name: "gitlab ci",
+			env: map[string]string{
+				"GITLAB_CI": "true", "CI_SERVER_URL": "https://gitlab.example.com", "CI_API_V4_URL": "https://gitlab.example.com/api/v4",
+				"CI_PROJECT_PATH": "group/docs", "CI_COMMIT_REF_NAME": "develop",
+				"CI_PIPELINE_URL": "https://gitlab.example.com/group/docs/-/pipelines/9",
+			},
+			expected: CIEnv{
+				Forge: ForgeGitLab, ServerURL: "https://gitlab.example.com", APIURL: "https://gitlab.example.com/api/v4",
+				Repo: "group/docs", Branch: "develop", RunURL: "https://gitlab.example.com/group/docs/-/pipelines/9",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range ciVars {
+				t.Setenv(name, tt.env[name])
+			}
+			if got := DetectCI(); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("DetectCI = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewForge(t *testing.T) {
+	for _, name := range ciVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("GITLAB_CI", "true")
+	t.Setenv("CI_SERVER_URL", "https://gitlab.example.com")
+	t.Setenv("CI_PROJECT_PATH", "group/docs")
+	t.Setenv("GITLAB_TOKEN", "token")
+	t.Setenv("GITEA_TOKEN", "token")
+
+	forge, err := NewForge(ForgeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := forge.SourceURL("main", "docs/apps/plex.md"); got != "https://gitlab.example.com/group/docs/-/blob/main/docs/apps/plex.md" {
+		t.Errorf("detected forge SourceURL = %s", got)
+	}
+
+	// Configured forges ignore the CI values of another forge
+	forge, err = NewForge(ForgeOptions{Type: ForgeGitea, ServerURL: "https://git.example.com/", Repo: "saltyorg/docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := forge.SourceURL("main", "docs/apps/plex.md"); got != "https://git.example.com/saltyorg/docs/src/branch/main/docs/apps/plex.md" {
+		t.Errorf("gitea SourceURL = %s", got)
+	}
+
+	if _, err := NewForge(ForgeOptions{Type: ForgeGitea}); err == nil {
+		t.Error("expected an error for a forge without repository")
+	}
+}
+
+func TestNewSourceForge(t *testing.T) {
+	for _, name := range ciVars {
+		t.Setenv(name, "")
+	}
+	t.Setenv("GITEA_ACTIONS", "true")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_SERVER_URL", "https://git.example.com")
+	t.Setenv("GITHUB_REPOSITORY", "saltyorg/docs")
+	t.Setenv("GITEA_TOKEN", "")
+	t.Setenv("GITHUB_TOKEN", "")
+
+	// No token is needed for source links
+	forge, err := NewSourceForge(ForgeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := forge.SourceURL("main", "docs/apps/plex.md"); got != "https://git.example.com/saltyorg/docs/src/branch/main/docs/apps/plex.md" {
+		t.Errorf("gitea actions SourceURL = %s", got)
+	}
+
+	forge, err = NewSourceForge(ForgeOptions{Type: ForgeGitHub, Repo: "saltyorg/docs"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := forge.SourceURL("main", "docs/apps/plex.md"); got != "https://github.com/saltyorg/docs/blob/main/docs/apps/plex.md" {
+		t.Errorf("github SourceURL = %s", got)
+	}
+}
+
+// recordingServer answers requests from responses keyed by "METHOD path"
+// and records "METHOD path body" for each request.
+func recordingServer(t *testing.T, responses map[string]string) (*[]string, *httptest.Server) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		key := r.Method + " " + r.URL.EscapedPath()
+		requests = append(requests, strings.TrimSpace(key+" "+string(body)))
+		response, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"404 Not Found"}`)
+			return
+		}
+		fmt.Fprint(w, response)
+	}))
+	t.Cleanup(server.Close)
+	return &requests, server
+}
+
+func TestGitLabClient(t *testing.T) {
+	issue := `{"iid": 3, "title": "t", "description": "old", "state": "closed"}`
+	requests, server := recordingServer(t, map[string]string{
+		"GET /api/v4/projects/group%2Fdocs/issues":          "[" + issue + "]",
+		"POST /api/v4/projects/group%2Fdocs/issues":         `{"iid": 4, "state": "opened"}`,
+		"PUT /api/v4/projects/group%2Fdocs/issues/3":        issue,
+		"POST /api/v4/projects/group%2Fdocs/issues/3/notes": `{}`,
+	})
+	client := NewGitLabClient("group/docs", "token", "https://gitlab.example.com", server.URL+"/api/v4")
+
+	found, err := client.FindIssue("docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (&Issue{Number: 3, Title: "t", Body: "old", State: StateClosed}); !reflect.DeepEqual(found, expected) {
+		t.Errorf("FindIssue = %+v, want %+v", found, expected)
+	}
+	created, err := client.CreateIssue("Title", "Body", "docs")
+	if err != nil || created.Number != 4 || created.State != StateOpen {
+		t.Errorf("CreateIssue = %+v, %v", created, err)
+	}
+	for _, err := range []error{
+		client.UpdateIssue(3, "Title", "Body"),
+		client.ReopenIssue(3),
+		client.AddComment(3, "Done"),
+		client.CloseIssue(3),
+		client.PinIssue(found),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{
+		"GET /api/v4/projects/group%2Fdocs/issues",
+		`POST /api/v4/projects/group%2Fdocs/issues {"description":"Body","labels":"docs","title":"Title"}`,
+		`PUT /api/v4/projects/group%2Fdocs/issues/3 {"description":"Body","title":"Title"}`,
+		`PUT /api/v4/projects/group%2Fdocs/issues/3 {"state_event":"reopen"}`,
+		`POST /api/v4/projects/group%2Fdocs/issues/3/notes {"body":"Done"}`,
+		`PUT /api/v4/projects/group%2Fdocs/issues/3 {"state_event":"close"}`,
+	}
+	if !reflect.DeepEqual(*requests, expected) {
+		t.Errorf("requests =\n%s\nwant\n%s", strings.Join(*requests, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestGiteaClient(t *testing.T) {
+	requests, server := recordingServer(t, map[string]string{
+		"GET /api/v1/repos/saltyorg/docs/labels":        `[{"id": 1, "name": "bug"}]`,
+		"POST /api/v1/repos/saltyorg/docs/labels":       `{"id": 5, "name": "docs"}`,
+		"POST /api/v1/repos/saltyorg/docs/issues":       `{"number": 8, "state": "open"}`,
+		"POST /api/v1/repos/saltyorg/docs/issues/8/pin": ``,
+		"PATCH /api/v1/repos/saltyorg/docs/issues/8":    `{"number": 8, "state": "closed"}`,
+	})
+	client := NewGiteaClient("saltyorg/docs", "token", "https://git.example.com", server.URL+"/api/v1")
+
+	issue, err := client.CreateIssue("Title", "Body", "docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PinIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.CloseIssue(issue.Number); err != nil {
+		t.Fatal(err)
+	}
+
+	var created map[string]any
+	for _, request := range *requests {
+		if body, ok := strings.CutPrefix(request, "POST /api/v1/repos/saltyorg/docs/issues "); ok {
+			_ = json.Unmarshal([]byte(body), &created)
+		}
+	}
+	if labels, _ := created["labels"].([]any); len(labels) != 1 || labels[0] != float64(5) {
+		t.Errorf("issue created with labels %v, want [5]", created["labels"])
+	}
+	if last := (*requests)[len(*requests)-1]; last != `PATCH /api/v1/repos/saltyorg/docs/issues/8 {"state":"closed"}` {
+		t.Errorf("last request = %s", last)
+	}
+}
+
+func TestGiteaClient_LabelOnLaterPage(t *testing.T) {
+	var posted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			posted = true
+			fmt.Fprint(w, `{"id": 99, "name": "docs"}`)
+			return
+		}
+		// A full first page of other labels, then the label on page 2
+		var labels []string
+		if r.URL.Query().Get("page") == "1" {
+			for i := 1; i <= 50; i++ {
+				labels = append(labels, fmt.Sprintf(`{"id": %d, "name": "label-%d"}`, i, i))
+			}
+		} else {
+			labels = append(labels, `{"id": 51, "name": "docs"}`)
+		}
+		fmt.Fprint(w, "["+strings.Join(labels, ",")+"]")
+	}))
+	defer server.Close()
+	client := NewGiteaClient("saltyorg/docs", "token", "https://git.example.com", server.URL+"/api/v1")
+
+	id, err := client.labelID("docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 51 || posted {
+		t.Errorf("labelID = %d (created: %v), want the existing label 51", id, posted)
+	}
+}
@@ -0,0 +1,239 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GHClient is an IssueClient backed by the gh CLI, which must be installed
+// and authenticated.
+type GHClient struct {
+	repo string // Repository in format "owner/repo"
+}
+
+// NewGHClient returns a gh CLI issue client for repo.
+func NewGHClient(repo string) (*GHClient, error) {
+	if err := requireGH(); err != nil {
+		return nil, err
+	}
+	return &GHClient{repo: repo}, nil
+}
+
+// requireGH checks that the gh CLI is installed.
+func requireGH() error {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return fmt.Errorf("gh CLI not found: %w", err)
+	}
+	return nil
+}
+
+// ghIssue represents a GitHub issue from gh CLI JSON output.
+type ghIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+	NodeID string `json:"id"` // GraphQL node ID for pinning
+}
+
+// run runs gh with args and returns its standard output.
+func (c *GHClient) run(args ...string) (string, error) {
+	cmd := exec.Command("gh", args...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w", stderr.String(), err)
+	}
+	return stdout.String(), nil
+}
+
+// FindIssue finds the most recent issue with the given label.
+func (c *GHClient) FindIssue(label string) (*Issue, error) {
+	out, err := c.run("issue", "list",
+		"--repo", c.repo,
+		"--label", label,
+		"--state", "all",
+		"--limit", "1",
+		"--json", "number,title,body,state,id")
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ghIssue
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		return nil, fmt.Errorf("parsing issue list: %w", err)
+	}
+
+	if len(issues) == 0 {
+		return nil, nil
+	}
+
+	issue := issues[0]
+	return &Issue{
+		Number: issue.Number,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		State:  strings.ToLower(issue.State),
+		NodeID: issue.NodeID,
+	}, nil
+}
+
+// ListIssues returns all open issues with the label.
+func (c *GHClient) ListIssues(label string) ([]*Issue, error) {
+	out, err := c.run("issue", "list",
+		"--repo", c.repo,
+		"--label", label,
+		"--state", "open",
+		"--limit", "1000",
+		"--json", "number,title,body,state,id")
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []ghIssue
+	if err := json.Unmarshal([]byte(out), &issues); err != nil {
+		return nil, fmt.Errorf("parsing issue list: %w", err)
+	}
+
+	result := make([]*Issue, 0, len(issues))
+	for _, issue := range issues {
+		result = append(result, &Issue{
+			Number: issue.Number,
+			Title:  issue.Title,
+			Body:   issue.Body,
+			State:  strings.ToLower(issue.State),
+			NodeID: issue.NodeID,
+		})
+	}
+	return result, nil
+}
+
+// CreateIssue creates a new issue.
+func (c *GHClient) CreateIssue(title, body, label string) (*Issue, error) {
+	out, err := c.run("issue", "create",
+		"--repo", c.repo,
+		"--title", title,
+		"--body", body,
+		"--label", label)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse issue number from URL output (e.g., "https://github.com/owner/repo/issues/123")
+	output := strings.TrimSpace(out)
+	parts := strings.Split(output, "/")
+	if len(parts) > 0 {
+		var num int
+		if _, err := fmt.Sscanf(parts[len(parts)-1], "%d", &num); err == nil {
+			return &Issue{Number: num, Title: title, Body: body, State: StateOpen}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not parse issue number from: %s", output)
+}
+
+// UpdateIssue updates the title and body of an issue.
+func (c *GHClient) UpdateIssue(number int, title, body string) error {
+	_, err := c.run("issue", "edit",
+		"--repo", c.repo,
+		fmt.Sprintf("%d", number),
+		"--title", title,
+		"--body", body)
+	return err
+}
+
+// CloseIssue closes an issue.
+func (c *GHClient) CloseIssue(number int) error {
+	_, err := c.run("issue", "close", "--repo", c.repo, fmt.Sprintf("%d", number))
+	return err
+}
+
+// ReopenIssue reopens a closed issue.
+func (c *GHClient) ReopenIssue(number int) error {
+	_, err := c.run("issue", "reopen", "--repo", c.repo, fmt.Sprintf("%d", number))
+	return err
+}
+
+// AddComment adds a comment to an issue.
+func (c *GHClient) AddComment(number int, body string) error {
+	_, err := c.run("issue", "comment",
+		"--repo", c.repo,
+		fmt.Sprintf("%d", number),
+		"--body", body)
+	return err
+}
+
+// PinIssue pins an issue to the repository.
+func (c *GHClient) PinIssue(issue *Issue) error {
+	_, err := c.run("issue", "pin", "--repo", c.repo, fmt.Sprintf("%d", issue.Number))
+	return err
+}
+
+// UnpinIssue unpins an issue from the repository.
+func (c *GHClient) UnpinIssue(issue *Issue) error {
+	_, err := c.run("issue", "unpin", "--repo", c.repo, fmt.Sprintf("%d", issue.Number))
+	return err
+}
+
+// FindPullRequest returns the open pull request from head into base, or nil.
+func (c *GHClient) FindPullRequest(head, base string) (*PullRequest, error) {
+	out, err := c.run("pr", "list",
+		"--repo", c.repo,
+		"--head", head,
+		"--base", base,
+		"--state", "open",
+		"--limit", "1",
+		"--json", "number,url")
+	if err != nil {
+		return nil, err
+	}
+
+	var pulls []struct {
+		Number int    `json:"number"`
+		URL    string `json:"url"`
+	}
+	if err := json.Unmarshal([]byte(out), &pulls); err != nil {
+		return nil, fmt.Errorf("parsing pull request list: %w", err)
+	}
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	return &PullRequest{Number: pulls[0].Number, URL: pulls[0].URL}, nil
+}
+
+// CreatePullRequest opens a pull request from head into base.
+func (c *GHClient) CreatePullRequest(head, base, title, body string) (*PullRequest, error) {
+	out, err := c.run("pr", "create",
+		"--repo", c.repo,
+		"--head", head,
+		"--base", base,
+		"--title", title,
+		"--body", body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the number from the URL output (e.g., "https://github.com/owner/repo/pull/123")
+	output := strings.TrimSpace(out)
+	var num int
+	if _, err := fmt.Sscanf(output[strings.LastIndex(output, "/")+1:], "%d", &num); err != nil {
+		return nil, fmt.Errorf("could not parse pull request number from: %s", output)
+	}
+	return &PullRequest{Number: num, URL: output}, nil
+}
+
+// UpdatePullRequest updates the title and body of a pull request.
+func (c *GHClient) UpdatePullRequest(number int, title, body string) error {
+	_, err := c.run("pr", "edit",
+		"--repo", c.repo,
+		fmt.Sprintf("%d", number),
+		"--title", title,
+		"--body", body)
+	return err
+}
@@ -0,0 +1,229 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GiteaClient is a Forge backed by the Gitea (or Forgejo) REST API.
+type GiteaClient struct {
+	BaseURL   string       // API base URL, e.g. https://gitea.example.com/api/v1
+	ServerURL string       // Web URL, e.g. https://gitea.example.com
+	Token     string       // Access token
+	Client    *http.Client // HTTP client, a 30s timeout client when nil
+	repo      string       // Repository in format "owner/repo"
+}
+
+// NewGiteaClient returns a Gitea forge for repo.
+func NewGiteaClient(repo, token, serverURL, apiURL string) *GiteaClient {
+	return &GiteaClient{
+		BaseURL:   strings.TrimSuffix(apiURL, "/"),
+		ServerURL: strings.TrimSuffix(serverURL, "/"),
+		Token:     token,
+		repo:      repo,
+	}
+}
+
+// giteaIssue is an issue in Gitea API responses.
+type giteaIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"`
+}
+
+func (i giteaIssue) issue() *Issue {
+	return &Issue{Number: i.Number, Title: i.Title, Body: i.Body, State: i.State}
+}
+
+func (c *GiteaClient) do(method, endpoint string, in, out any) error {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	if c.Token != "" {
+		header.Set("Authorization", "token "+c.Token)
+	}
+	return doJSON(c.Client, header, method, endpoint, in, out)
+}
+
+// repoURL returns the URL of a repository API path, e.g. repoURL("issues", "3").
+func (c *GiteaClient) repoURL(parts ...string) string {
+	endpoint := fmt.Sprintf("%s/repos/%s", c.BaseURL, c.repo)
+	for _, part := range parts {
+		endpoint += "/" + part
+	}
+	return endpoint
+}
+
+// FindIssue finds the most recently created issue with the given label.
+func (c *GiteaClient) FindIssue(label string) (*Issue, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "all")
+	query.Set("type", "issues")
+	query.Set("limit", "1")
+
+	var issues []giteaIssue
+	if err := c.do(http.MethodGet, c.repoURL("issues")+"?"+query.Encode(), nil, &issues); err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	return issues[0].issue(), nil
+}
+
+// ListIssues returns all open issues with the label.
+func (c *GiteaClient) ListIssues(label string) ([]*Issue, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "open")
+	query.Set("type", "issues")
+	query.Set("limit", "50")
+
+	var result []*Issue
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprint(page))
+		var issues []giteaIssue
+		if err := c.do(http.MethodGet, c.repoURL("issues")+"?"+query.Encode(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			result = append(result, issue.issue())
+		}
+		if len(issues) < 50 {
+			return result, nil
+		}
+	}
+}
+
+// CreateIssue creates a new issue. Gitea assigns labels by ID, so the
+// label is looked up and created when missing.
+func (c *GiteaClient) CreateIssue(title, body, label string) (*Issue, error) {
+	labelID, err := c.labelID(label)
+	if err != nil {
+		return nil, fmt.Errorf("resolving label %q: %w", label, err)
+	}
+
+	in := map[string]any{"title": title, "body": body, "labels": []int64{labelID}}
+	var created giteaIssue
+	if err := c.do(http.MethodPost, c.repoURL("issues"), in, &created); err != nil {
+		return nil, err
+	}
+	return created.issue(), nil
+}
+
+// labelID returns the ID of a repository label, creating the label when missing.
+func (c *GiteaClient) labelID(name string) (int64, error) {
+	type giteaLabel struct {
+		ID   int64  `json:"id"`
+		Name string `json:"name"`
+	}
+
+	for page := 1; ; page++ {
+		var labels []giteaLabel
+		endpoint := fmt.Sprintf("%s?limit=50&page=%d", c.repoURL("labels"), page)
+		if err := c.do(http.MethodGet, endpoint, nil, &labels); err != nil {
+			return 0, err
+		}
+		for _, label := range labels {
+			if label.Name == name {
+				return label.ID, nil
+			}
+		}
+		if len(labels) < 50 {
+			break
+		}
+	}
+
+	var created giteaLabel
+	in := map[string]string{"name": name, "color": "#ededed"}
+	if err := c.do(http.MethodPost, c.repoURL("labels"), in, &created); err != nil {
+		return 0, err
+	}
+	return created.ID, nil
+}
+
+// UpdateIssue updates the title and body of an issue.
+func (c *GiteaClient) UpdateIssue(number int, title, body string) error {
+	in := map[string]string{"title": title, "body": body}
+	return c.do(http.MethodPatch, c.repoURL("issues", fmt.Sprint(number)), in, nil)
+}
+
+// CloseIssue closes an issue.
+func (c *GiteaClient) CloseIssue(number int) error {
+	return c.do(http.MethodPatch, c.repoURL("issues", fmt.Sprint(number)), map[string]string{"state": StateClosed}, nil)
+}
+
+// ReopenIssue reopens a closed issue.
+func (c *GiteaClient) ReopenIssue(number int) error {
+	return c.do(http.MethodPatch, c.repoURL("issues", fmt.Sprint(number)), map[string]string{"state": StateOpen}, nil)
+}
+
+// AddComment adds a comment to an issue.
+func (c *GiteaClient) AddComment(number int, body string) error {
+	return c.do(http.MethodPost, c.repoURL("issues", fmt.Sprint(number), "comments"), map[string]string{"body": body}, nil)
+}
+
+// PinIssue pins an issue to the repository (Gitea 1.21 and later).
+func (c *GiteaClient) PinIssue(issue *Issue) error {
+	return c.do(http.MethodPost, c.repoURL("issues", fmt.Sprint(issue.Number), "pin"), nil, nil)
+}
+
+// UnpinIssue unpins an issue from the repository.
+func (c *GiteaClient) UnpinIssue(issue *Issue) error {
+	return c.do(http.MethodDelete, c.repoURL("issues", fmt.Sprint(issue.Number), "pin"), nil, nil)
+}
+
+// SourceURL returns the web URL of a file at a branch.
+func (c *GiteaClient) SourceURL(branch, path string) string {
+	return fmt.Sprintf("%s/%s/src/branch/%s/%s", c.ServerURL, c.repo, branch, path)
+}
+
+// giteaPullRequest is a pull request in Gitea API responses.
+type giteaPullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+}
+
+// FindPullRequest returns the open pull request from head into base, or nil.
+func (c *GiteaClient) FindPullRequest(head, base string) (*PullRequest, error) {
+	for page := 1; ; page++ {
+		var pulls []giteaPullRequest
+		endpoint := fmt.Sprintf("%s?state=open&limit=50&page=%d", c.repoURL("pulls"), page)
+		if err := c.do(http.MethodGet, endpoint, nil, &pulls); err != nil {
+			return nil, err
+		}
+		for _, pull := range pulls {
+			if pull.Head.Ref == head && pull.Base.Ref == base {
+				return &PullRequest{Number: pull.Number, URL: pull.HTMLURL}, nil
+			}
+		}
+		if len(pulls) < 50 {
+			return nil, nil
+		}
+	}
+}
+
+// CreatePullRequest opens a pull request from head into base.
+func (c *GiteaClient) CreatePullRequest(head, base, title, body string) (*PullRequest, error) {
+	in := map[string]string{"head": head, "base": base, "title": title, "body": body}
+	var created giteaPullRequest
+	if err := c.do(http.MethodPost, c.repoURL("pulls"), in, &created); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: created.Number, URL: created.HTMLURL}, nil
+}
+
+// UpdatePullRequest updates the title and body of a pull request.
+func (c *GiteaClient) UpdatePullRequest(number int, title, body string) error {
+	in := map[string]string{"title": title, "body": body}
+	return c.do(http.MethodPatch, c.repoURL("pulls", fmt.Sprint(number)), in, nil)
+}
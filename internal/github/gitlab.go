@@ -0,0 +1,196 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GitLabClient is a Forge backed by the GitLab REST API (v4).
+type GitLabClient struct {
+	BaseURL   string       // API base URL, e.g. https://gitlab.com/api/v4
+	ServerURL string       // Web URL, e.g. https://gitlab.com
+	Token     string       // Personal, project or group access token
+	Client    *http.Client // HTTP client, a 30s timeout client when nil
+	project   string       // Project path, e.g. "saltyorg/docs"
+}
+
+// NewGitLabClient returns a GitLab forge for a project path.
+func NewGitLabClient(project, token, serverURL, apiURL string) *GitLabClient {
+	return &GitLabClient{
+		BaseURL:   strings.TrimSuffix(apiURL, "/"),
+		ServerURL: strings.TrimSuffix(serverURL, "/"),
+		Token:     token,
+		project:   project,
+	}
+}
+
+// gitlabIssue is an issue in GitLab API responses.
+type gitlabIssue struct {
+	IID         int    `json:"iid"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"` // "opened" or "closed"
+}
+
+func (i gitlabIssue) issue() *Issue {
+	state := StateOpen
+	if i.State == "closed" {
+		state = StateClosed
+	}
+	return &Issue{Number: i.IID, Title: i.Title, Body: i.Description, State: state}
+}
+
+func (c *GitLabClient) do(method, endpoint string, in, out any) error {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	if c.Token != "" {
+		header.Set("PRIVATE-TOKEN", c.Token)
+	}
+	return doJSON(c.Client, header, method, endpoint, in, out)
+}
+
+// issuesURL returns the URL of the project's issues, or of one issue's subresource.
+func (c *GitLabClient) issuesURL(parts ...string) string {
+	endpoint := fmt.Sprintf("%s/projects/%s/issues", c.BaseURL, url.PathEscape(c.project))
+	for _, part := range parts {
+		endpoint += "/" + part
+	}
+	return endpoint
+}
+
+// FindIssue finds the most recently created issue with the given label.
+func (c *GitLabClient) FindIssue(label string) (*Issue, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("order_by", "created_at")
+	query.Set("sort", "desc")
+	query.Set("per_page", "1")
+
+	var issues []gitlabIssue
+	if err := c.do(http.MethodGet, c.issuesURL()+"?"+query.Encode(), nil, &issues); err != nil {
+		return nil, err
+	}
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	return issues[0].issue(), nil
+}
+
+// ListIssues returns all open issues with the label.
+func (c *GitLabClient) ListIssues(label string) ([]*Issue, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "opened")
+	query.Set("per_page", "100")
+
+	var result []*Issue
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprint(page))
+		var issues []gitlabIssue
+		if err := c.do(http.MethodGet, c.issuesURL()+"?"+query.Encode(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			result = append(result, issue.issue())
+		}
+		if len(issues) < 100 {
+			return result, nil
+		}
+	}
+}
+
+// CreateIssue creates a new issue.
+func (c *GitLabClient) CreateIssue(title, body, label string) (*Issue, error) {
+	in := map[string]string{"title": title, "description": body, "labels": label}
+	var created gitlabIssue
+	if err := c.do(http.MethodPost, c.issuesURL(), in, &created); err != nil {
+		return nil, err
+	}
+	return created.issue(), nil
+}
+
+// UpdateIssue updates the title and description of an issue.
+func (c *GitLabClient) UpdateIssue(number int, title, body string) error {
+	in := map[string]string{"title": title, "description": body}
+	return c.do(http.MethodPut, c.issuesURL(fmt.Sprint(number)), in, nil)
+}
+
+// CloseIssue closes an issue.
+func (c *GitLabClient) CloseIssue(number int) error {
+	return c.do(http.MethodPut, c.issuesURL(fmt.Sprint(number)), map[string]string{"state_event": "close"}, nil)
+}
+
+// ReopenIssue reopens a closed issue.
+func (c *GitLabClient) ReopenIssue(number int) error {
+	return c.do(http.MethodPut, c.issuesURL(fmt.Sprint(number)), map[string]string{"state_event": "reopen"}, nil)
+}
+
+// AddComment adds a note to an issue.
+func (c *GitLabClient) AddComment(number int, body string) error {
+	return c.do(http.MethodPost, c.issuesURL(fmt.Sprint(number), "notes"), map[string]string{"body": body}, nil)
+}
+
+// PinIssue is a no-op: GitLab has no pinned issues.
+func (c *GitLabClient) PinIssue(issue *Issue) error {
+	return nil
+}
+
+// UnpinIssue is a no-op: GitLab has no pinned issues.
+func (c *GitLabClient) UnpinIssue(issue *Issue) error {
+	return nil
+}
+
+// SourceURL returns the web URL of a file at a branch.
+func (c *GitLabClient) SourceURL(branch, path string) string {
+	return fmt.Sprintf("%s/%s/-/blob/%s/%s", c.ServerURL, c.project, branch, path)
+}
+
+// gitlabMergeRequest is a merge request in GitLab API responses.
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+// mergeRequestsURL returns the URL of the project's merge requests, or of one merge request.
+func (c *GitLabClient) mergeRequestsURL(parts ...string) string {
+	endpoint := fmt.Sprintf("%s/projects/%s/merge_requests", c.BaseURL, url.PathEscape(c.project))
+	for _, part := range parts {
+		endpoint += "/" + part
+	}
+	return endpoint
+}
+
+// FindPullRequest returns the open merge request from head into base, or nil.
+func (c *GitLabClient) FindPullRequest(head, base string) (*PullRequest, error) {
+	query := url.Values{}
+	query.Set("state", "opened")
+	query.Set("source_branch", head)
+	query.Set("target_branch", base)
+
+	var requests []gitlabMergeRequest
+	if err := c.do(http.MethodGet, c.mergeRequestsURL()+"?"+query.Encode(), nil, &requests); err != nil {
+		return nil, err
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	return &PullRequest{Number: requests[0].IID, URL: requests[0].WebURL}, nil
+}
+
+// CreatePullRequest opens a merge request from head into base.
+func (c *GitLabClient) CreatePullRequest(head, base, title, body string) (*PullRequest, error) {
+	in := map[string]string{"source_branch": head, "target_branch": base, "title": title, "description": body}
+	var created gitlabMergeRequest
+	if err := c.do(http.MethodPost, c.mergeRequestsURL(), in, &created); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: created.IID, URL: created.WebURL}, nil
+}
+
+// UpdatePullRequest updates the title and description of a merge request.
+func (c *GitLabClient) UpdatePullRequest(number int, title, body string) error {
+	in := map[string]string{"title": title, "description": body}
+	return c.do(http.MethodPut, c.mergeRequestsURL(fmt.Sprint(number)), in, nil)
+}
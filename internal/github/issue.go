@@ -1,25 +1,22 @@
 package github
 
 import (
-	"bytes"
-	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 )
 
-// IssueManager handles GitHub issue creation and management.
+// IssueManager handles the tracking issue on a forge.
 type IssueManager struct {
-	repo        string // Repository in format "owner/repo"
+	forge       Forge  // Issue tracker and source links
 	workflowURL string // URL to the workflow run
 	branch      string // Branch name for links
 }
 
-// NewIssueManager creates a new GitHub issue manager.
-func NewIssueManager(repo, workflowURL string) *IssueManager {
+// NewIssueManager creates a new issue manager for a forge.
+func NewIssueManager(forge Forge, workflowURL string) *IssueManager {
 	return &IssueManager{
-		repo:        repo,
+		forge:       forge,
 		workflowURL: workflowURL,
 		branch:      GetBranch(),
 	}
@@ -31,20 +28,28 @@ type CheckResult struct {
 	MissingSections         []string // Docs without managed variables sections
 	MissingOverviewSections []string // Docs without managed overview sections
 	OrphanedDocs            []string // Docs without corresponding roles
+	InvalidInstallTags      []string // "sb install" tags not defined in the playbooks ("path:line: tag")
+	BrokenLinks             []string // Unreachable or malformed external links ("path:line: url (reason)")
 }
 
 // HasIssues returns true if there are any problems.
 func (r *CheckResult) HasIssues() bool {
-	return len(r.MissingDocs) > 0 || len(r.MissingSections) > 0 || len(r.MissingOverviewSections) > 0 || len(r.OrphanedDocs) > 0
+	return r.TotalIssues() > 0
 }
 
 // TotalIssues returns the total number of issues.
 func (r *CheckResult) TotalIssues() int {
-	return len(r.MissingDocs) + len(r.MissingSections) + len(r.MissingOverviewSections) + len(r.OrphanedDocs)
+	return len(r.MissingDocs) + len(r.MissingSections) + len(r.MissingOverviewSections) + len(r.OrphanedDocs) + len(r.InvalidInstallTags) + len(r.BrokenLinks)
 }
 
 // GenerateIssueBody generates the markdown body for a GitHub issue.
 func (m *IssueManager) GenerateIssueBody(result *CheckResult) string {
+	return m.generateIssueBody(result, nil)
+}
+
+// generateIssueBody generates the issue body. Problems with an entry in
+// children (issue numbers by fingerprint) link to their own issue.
+func (m *IssueManager) generateIssueBody(result *CheckResult, children map[string]int) string {
 	var builder strings.Builder
 
 	builder.WriteString("## 📝 Documentation Status\n\n")
@@ -53,7 +58,7 @@ func (m *IssueManager) GenerateIssueBody(result *CheckResult) string {
 		builder.WriteString(fmt.Sprintf("### Missing Documentation (%d)\n", len(result.MissingDocs)))
 		builder.WriteString("Roles without corresponding documentation pages:\n\n")
 		for _, role := range result.MissingDocs {
-			builder.WriteString(fmt.Sprintf("- [ ] `%s`\n", role))
+			builder.WriteString(fmt.Sprintf("- [ ] %s`%s`\n", childRef(children, KindMissingDocs, role), role))
 		}
 		builder.WriteString("\n")
 	}
@@ -62,10 +67,10 @@ func (m *IssueManager) GenerateIssueBody(result *CheckResult) string {
 		builder.WriteString(fmt.Sprintf("### Missing Variables Sections (%d)\n", len(result.MissingSections)))
 		builder.WriteString("Documentation pages without the managed variables section:\n\n")
 		for _, doc := range result.MissingSections {
-			// Convert path to GitHub link
+			// Convert path to a source link
 			docName := extractDocName(doc)
-			link := fmt.Sprintf("https://github.com/%s/blob/%s/%s", m.repo, m.branch, doc)
-			builder.WriteString(fmt.Sprintf("- [ ] [%s](%s)\n", docName, link))
+			link := m.forge.SourceURL(m.branch, doc)
+			builder.WriteString(fmt.Sprintf("- [ ] %s[%s](%s)\n", childRef(children, KindMissingSections, doc), docName, link))
 		}
 		builder.WriteString("\n")
 	}
@@ -74,9 +79,9 @@ func (m *IssueManager) GenerateIssueBody(result *CheckResult) string {
 		builder.WriteString(fmt.Sprintf("### Missing Overview Sections (%d)\n", len(result.MissingOverviewSections)))
 		builder.WriteString("Documentation pages without the managed overview section:\n\n")
 		for _, doc := range result.MissingOverviewSections {
-			// Convert path to GitHub link
+			// Convert path to a source link
 			docName := extractDocName(doc)
-			link := fmt.Sprintf("https://github.com/%s/blob/%s/%s", m.repo, m.branch, doc)
+			link := m.forge.SourceURL(m.branch, doc)
 			builder.WriteString(fmt.Sprintf("- [ ] [%s](%s)\n", docName, link))
 		}
 		builder.WriteString("\n")
@@ -86,7 +91,25 @@ func (m *IssueManager) GenerateIssueBody(result *CheckResult) string {
 		builder.WriteString(fmt.Sprintf("### Orphaned Documentation (%d)\n", len(result.OrphanedDocs)))
 		builder.WriteString("Documentation pages without corresponding roles:\n\n")
 		for _, doc := range result.OrphanedDocs {
-			builder.WriteString(fmt.Sprintf("- [ ] `%s`\n", doc))
+			builder.WriteString(fmt.Sprintf("- [ ] %s`%s`\n", childRef(children, KindOrphanedDocs, doc), doc))
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(result.InvalidInstallTags) > 0 {
+		builder.WriteString(fmt.Sprintf("### Invalid Install Tags (%d)\n", len(result.InvalidInstallTags)))
+		builder.WriteString("Install commands referencing tags that are not defined in the playbooks:\n\n")
+		for _, entry := range result.InvalidInstallTags {
+			builder.WriteString(fmt.Sprintf("- [ ] `%s`\n", entry))
+		}
+		builder.WriteString("\n")
+	}
+
+	if len(result.BrokenLinks) > 0 {
+		builder.WriteString(fmt.Sprintf("### Broken Links (%d)\n", len(result.BrokenLinks)))
+		builder.WriteString("External links in frontmatter or managed sections that are malformed or unreachable:\n\n")
+		for _, entry := range result.BrokenLinks {
+			builder.WriteString(fmt.Sprintf("- [ ] `%s`\n", entry))
 		}
 		builder.WriteString("\n")
 	}
@@ -96,10 +119,19 @@ func (m *IssueManager) GenerateIssueBody(result *CheckResult) string {
 		builder.WriteString(fmt.Sprintf("**Workflow run:** [link](%s)\n", m.workflowURL))
 	}
 	builder.WriteString("*This issue is automatically managed by docs-automation*\n")
+	builder.WriteString(newIssueState(result, children).stateBlock())
 
 	return builder.String()
 }
 
+// childRef returns the "#N " reference to the issue of a problem, or "".
+func childRef(children map[string]int, kind, entry string) string {
+	if number, ok := children[Fingerprint(kind, entry)]; ok {
+		return fmt.Sprintf("#%d ", number)
+	}
+	return ""
+}
+
 // GenerateIssueTitle generates the issue title.
 func (m *IssueManager) GenerateIssueTitle(result *CheckResult) string {
 	count := result.TotalIssues()
@@ -138,6 +170,8 @@ func (m *IssueManager) OutputGitHubActions(result *CheckResult) {
 	fmt.Fprintf(f, "missing_sections=%d\n", len(result.MissingSections))
 	fmt.Fprintf(f, "missing_overview_sections=%d\n", len(result.MissingOverviewSections))
 	fmt.Fprintf(f, "orphaned_docs=%d\n", len(result.OrphanedDocs))
+	fmt.Fprintf(f, "invalid_install_tags=%d\n", len(result.InvalidInstallTags))
+	fmt.Fprintf(f, "broken_links=%d\n", len(result.BrokenLinks))
 
 	// For multiline output (issue body), use delimiter
 	if result.HasIssues() {
@@ -155,57 +189,33 @@ func extractDocName(path string) string {
 	return strings.TrimSuffix(name, ".md")
 }
 
-// GetWorkflowURL attempts to construct the workflow URL from environment variables.
+// GetWorkflowURL returns the URL of the current CI run, or "" outside CI.
 func GetWorkflowURL() string {
-	serverURL := os.Getenv("GITHUB_SERVER_URL")
-	repo := os.Getenv("GITHUB_REPOSITORY")
-	runID := os.Getenv("GITHUB_RUN_ID")
-
-	if serverURL == "" || repo == "" || runID == "" {
-		return ""
-	}
-
-	return fmt.Sprintf("%s/%s/actions/runs/%s", serverURL, repo, runID)
+	return DetectCI().RunURL
 }
 
-// GetRepository returns the repository from environment variables.
+// GetRepository returns the repository of the current CI run.
 func GetRepository() string {
-	return os.Getenv("GITHUB_REPOSITORY")
+	return DetectCI().Repo
 }
 
-// GetBranch returns the current branch name from environment variables.
-// For pull requests, it uses GITHUB_HEAD_REF; otherwise GITHUB_REF_NAME.
-// Falls back to "main" if not running in GitHub Actions.
+// GetBranch returns the current branch name from the CI environment: the
+// source branch of a pull/merge request, otherwise the ref name.
+// Falls back to "main" outside CI.
 func GetBranch() string {
-	// For pull requests, GITHUB_HEAD_REF contains the source branch
-	if headRef := os.Getenv("GITHUB_HEAD_REF"); headRef != "" {
-		return headRef
-	}
-	// For push events, GITHUB_REF_NAME contains the branch name
-	if refName := os.Getenv("GITHUB_REF_NAME"); refName != "" {
-		return refName
-	}
-	return "main"
+	return DetectCI().Branch
 }
 
-// ghIssue represents a GitHub issue from gh CLI JSON output.
-type ghIssue struct {
-	Number int    `json:"number"`
-	Title  string `json:"title"`
-	State  string `json:"state"`
-	NodeID string `json:"id"` // GraphQL node ID for pinning
-}
-
-// ManageIssue creates, updates, or closes a GitHub issue based on check results.
-// It uses the gh CLI which must be installed and authenticated.
+// ManageIssue creates, updates, or closes the tracking issue based on check results.
 func (m *IssueManager) ManageIssue(result *CheckResult, label string) error {
-	// Check if gh CLI is available
-	if _, err := exec.LookPath("gh"); err != nil {
-		return fmt.Errorf("gh CLI not found: %w", err)
-	}
+	return m.syncTrackingIssue(result, label, nil)
+}
 
+// syncTrackingIssue creates, updates, or closes the tracking issue. children
+// holds the per-role issue numbers by fingerprint, nil in single mode.
+func (m *IssueManager) syncTrackingIssue(result *CheckResult, label string, children map[string]int) error {
 	// Find existing issue with the label
-	existingIssue, err := m.findExistingIssue(label)
+	existingIssue, err := m.forge.FindIssue(label)
 	if err != nil {
 		return fmt.Errorf("finding existing issue: %w", err)
 	}
@@ -213,57 +223,73 @@ func (m *IssueManager) ManageIssue(result *CheckResult, label string) error {
 	if result.HasIssues() {
 		// Create or update issue
 		title := m.GenerateIssueTitle(result)
-		body := m.GenerateIssueBody(result)
+		body := m.generateIssueBody(result, children)
 
 		if existingIssue != nil {
+			// Skip the update when nothing changed since the previous run
+			previous, hasState := parseIssueState(existingIssue.Body)
+			if hasState && existingIssue.State != StateClosed && previous.unchanged(newIssueState(result, children)) {
+				fmt.Printf("Issue #%d is up to date\n", existingIssue.Number)
+				return nil
+			}
+
 			// Update existing issue
-			if err := m.updateIssue(existingIssue.Number, title, body); err != nil {
+			if err := m.forge.UpdateIssue(existingIssue.Number, title, body); err != nil {
 				return fmt.Errorf("updating issue: %w", err)
 			}
 			fmt.Printf("Updated issue #%d\n", existingIssue.Number)
 
 			// Reopen if closed
-			if existingIssue.State == "CLOSED" {
-				if err := m.reopenIssue(existingIssue.Number); err != nil {
+			if existingIssue.State == StateClosed {
+				if err := m.forge.ReopenIssue(existingIssue.Number); err != nil {
 					return fmt.Errorf("reopening issue: %w", err)
 				}
 				fmt.Printf("Reopened issue #%d\n", existingIssue.Number)
 			}
 
+			// Tell watchers what changed
+			if hasState {
+				if changes := describeChanges(previous.result(), result); changes != "" {
+					if err := m.forge.AddComment(existingIssue.Number, "**Changes since the last run:** "+changes); err != nil {
+						fmt.Printf("Note: could not add changes comment: %v\n", err)
+					}
+				}
+			}
+
 			// Pin if not already pinned
-			if err := m.pinIssue(existingIssue.Number); err != nil {
+			if err := m.forge.PinIssue(existingIssue); err != nil {
 				// Don't fail on pin errors - it might already be pinned or user lacks permission
 				fmt.Printf("Note: could not pin issue: %v\n", err)
 			}
 		} else {
 			// Create new issue
-			issueNum, err := m.createIssue(title, body, label)
+			issue, err := m.forge.CreateIssue(title, body, label)
 			if err != nil {
 				return fmt.Errorf("creating issue: %w", err)
 			}
-			fmt.Printf("Created issue #%d\n", issueNum)
+			fmt.Printf("Created issue #%d\n", issue.Number)
 
 			// Pin the new issue
-			if err := m.pinIssue(issueNum); err != nil {
+			if err := m.forge.PinIssue(issue); err != nil {
 				fmt.Printf("Note: could not pin issue: %v\n", err)
 			}
 		}
 	} else {
 		// No issues - close existing issue if present
-		if existingIssue != nil && existingIssue.State != "CLOSED" {
+		if existingIssue != nil && existingIssue.State != StateClosed {
 			// Unpin first
-			if err := m.unpinIssue(existingIssue.Number); err != nil {
+			if err := m.forge.UnpinIssue(existingIssue); err != nil {
 				fmt.Printf("Note: could not unpin issue: %v\n", err)
 			}
 
 			// Add closing comment
 			closeMsg := "✅ All documentation checks passed! Closing this issue."
-			if err := m.addComment(existingIssue.Number, closeMsg); err != nil {
+			if err := m.forge.AddComment(existingIssue.Number, closeMsg); err != nil {
 				fmt.Printf("Note: could not add closing comment: %v\n", err)
 			}
 
 			// Close the issue
-			if err := m.closeIssue(existingIssue.Number); err != nil {
+			if err := m.forge.CloseIssue(existingIssue.Number); err != nil {
 				return fmt.Errorf("closing issue: %w", err)
 			}
 			fmt.Printf("Closed issue #%d\n", existingIssue.Number)
@@ -274,152 +300,3 @@ func (m *IssueManager) ManageIssue(result *CheckResult, label string) error {
 
 	return nil
 }
-
-// findExistingIssue finds an existing issue with the given label.
-func (m *IssueManager) findExistingIssue(label string) (*ghIssue, error) {
-	cmd := exec.Command("gh", "issue", "list",
-		"--repo", m.repo,
-		"--label", label,
-		"--state", "all",
-		"--limit", "1",
-		"--json", "number,title,state,id")
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	var issues []ghIssue
-	if err := json.Unmarshal(stdout.Bytes(), &issues); err != nil {
-		return nil, fmt.Errorf("parsing issue list: %w", err)
-	}
-
-	if len(issues) == 0 {
-		return nil, nil
-	}
-
-	return &issues[0], nil
-}
-
-// createIssue creates a new GitHub issue and returns its number.
-func (m *IssueManager) createIssue(title, body, label string) (int, error) {
-	cmd := exec.Command("gh", "issue", "create",
-		"--repo", m.repo,
-		"--title", title,
-		"--body", body,
-		"--label", label)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return 0, fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	// Parse issue number from URL output (e.g., "https://github.com/owner/repo/issues/123")
-	output := strings.TrimSpace(stdout.String())
-	parts := strings.Split(output, "/")
-	if len(parts) > 0 {
-		var num int
-		if _, err := fmt.Sscanf(parts[len(parts)-1], "%d", &num); err == nil {
-			return num, nil
-		}
-	}
-
-	return 0, fmt.Errorf("could not parse issue number from: %s", output)
-}
-
-// updateIssue updates an existing GitHub issue.
-func (m *IssueManager) updateIssue(number int, title, body string) error {
-	cmd := exec.Command("gh", "issue", "edit",
-		"--repo", m.repo,
-		fmt.Sprintf("%d", number),
-		"--title", title,
-		"--body", body)
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
-}
-
-// closeIssue closes a GitHub issue.
-func (m *IssueManager) closeIssue(number int) error {
-	cmd := exec.Command("gh", "issue", "close", "--repo", m.repo, fmt.Sprintf("%d", number))
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
-}
-
-// reopenIssue reopens a closed GitHub issue.
-func (m *IssueManager) reopenIssue(number int) error {
-	cmd := exec.Command("gh", "issue", "reopen", "--repo", m.repo, fmt.Sprintf("%d", number))
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
-}
-
-// addComment adds a comment to a GitHub issue.
-func (m *IssueManager) addComment(number int, body string) error {
-	cmd := exec.Command("gh", "issue", "comment",
-		"--repo", m.repo,
-		fmt.Sprintf("%d", number),
-		"--body", body)
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
-}
-
-// pinIssue pins an issue to the repository.
-func (m *IssueManager) pinIssue(number int) error {
-	cmd := exec.Command("gh", "issue", "pin", "--repo", m.repo, fmt.Sprintf("%d", number))
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
-}
-
-// unpinIssue unpins an issue from the repository.
-func (m *IssueManager) unpinIssue(number int) error {
-	cmd := exec.Command("gh", "issue", "unpin", "--repo", m.repo, fmt.Sprintf("%d", number))
-
-	var stderr bytes.Buffer
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s: %w", stderr.String(), err)
-	}
-
-	return nil
-}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckResult_OnlyBrokenLinks(t *testing.T) {
+	result := &CheckResult{BrokenLinks: []string{"docs/apps/plex.md:20: https://example.com (404 Not Found)"}}
+	if !result.HasIssues() || result.TotalIssues() != 1 {
+		t.Fatalf("HasIssues = %v, TotalIssues = %d; want true, 1", result.HasIssues(), result.TotalIssues())
+	}
+
+	manager := NewIssueManager(NewGitHubForge(nil, "https://github.com", "saltyorg/docs"), "")
+	if title := manager.GenerateIssueTitle(result); title != "[Docs Automation] 1 documentation issue found" {
+		t.Errorf("GenerateIssueTitle = %q", title)
+	}
+
+	output := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_OUTPUT", output)
+	manager.OutputGitHubActions(result)
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"has_issues=true\n", "total_issues=1\n", "broken_links=1\n", "### Broken Links (1)"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("GITHUB_OUTPUT is missing %q:\n%s", want, content)
+		}
+	}
+
+	summary := NewUpdateSummary()
+	summary.CheckResult = result
+	if markdown := summary.Markdown(); !strings.Contains(markdown, "**Broken Links:** 1 links") {
+		t.Errorf("summary is missing the broken links:\n%s", markdown)
+	}
+}
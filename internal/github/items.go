@@ -0,0 +1,183 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Issue modes of the tracking issue.
+const (
+	IssueModeSingle  = "single"   // One issue with a checklist of all problems
+	IssueModePerRole = "per-role" // One issue per problem, linked from a parent tracking issue
+)
+
+// Kinds of problems that get their own issue in per-role mode.
+const (
+	KindMissingDocs     = "missing-docs"
+	KindMissingSections = "missing-sections"
+	KindOrphanedDocs    = "orphaned-docs"
+)
+
+// fingerprintRe matches the fingerprint comment of a per-problem issue body.
+var fingerprintRe = regexp.MustCompile(`<!-- sb-docs-fingerprint: (.+?) -->`)
+
+// ItemLabel returns the label of the per-problem issues of a tracking label.
+func ItemLabel(label string) string {
+	return label + "-item"
+}
+
+// Fingerprint returns the stable identifier of a problem, e.g.
+// "missing-docs:plex". It is embedded in the issue body so the issue can be
+// matched with the problem on later runs regardless of its title.
+func Fingerprint(kind, entry string) string {
+	return kind + ":" + entry
+}
+
+// IssueFingerprint returns the fingerprint embedded in an issue body, or "".
+func IssueFingerprint(body string) string {
+	if match := fingerprintRe.FindStringSubmatch(body); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// issueItem is a problem that gets its own issue.
+type issueItem struct {
+	kind  string // KindMissingDocs, KindMissingSections or KindOrphanedDocs
+	entry string // Role name or doc path
+}
+
+func (i issueItem) fingerprint() string {
+	return Fingerprint(i.kind, i.entry)
+}
+
+// issueItems returns the problems of result that get their own issue.
+func issueItems(result *CheckResult) []issueItem {
+	var items []issueItem
+	for _, role := range result.MissingDocs {
+		items = append(items, issueItem{KindMissingDocs, role})
+	}
+	for _, doc := range result.MissingSections {
+		items = append(items, issueItem{KindMissingSections, doc})
+	}
+	for _, doc := range result.OrphanedDocs {
+		items = append(items, issueItem{KindOrphanedDocs, doc})
+	}
+	return items
+}
+
+// itemTitle returns the title of a problem's issue.
+func itemTitle(item issueItem) string {
+	switch item.kind {
+	case KindMissingDocs:
+		return fmt.Sprintf("[Docs Automation] Missing documentation for %s", item.entry)
+	case KindMissingSections:
+		return fmt.Sprintf("[Docs Automation] Missing variables section in %s", item.entry)
+	default:
+		return fmt.Sprintf("[Docs Automation] Orphaned documentation %s", item.entry)
+	}
+}
+
+// itemBody returns the body of a problem's issue.
+func (m *IssueManager) itemBody(item issueItem) string {
+	var builder strings.Builder
+
+	switch item.kind {
+	case KindMissingDocs:
+		builder.WriteString(fmt.Sprintf("The role `%s` has no documentation page.\n\n", item.entry))
+		builder.WriteString(fmt.Sprintf("Create one with `sb-docs scaffold %s`, fill in the overview and run `sb-docs update %s`.\n", item.entry, item.entry))
+	case KindMissingSections:
+		builder.WriteString(fmt.Sprintf("[%s](%s) has no managed variables section.\n\n", extractDocName(item.entry), m.forge.SourceURL(m.branch, item.entry)))
+		builder.WriteString("Add the variables section markers to the page and run `sb-docs update`.\n")
+	case KindOrphanedDocs:
+		builder.WriteString(fmt.Sprintf("`%s` has no corresponding role.\n\n", item.entry))
+		builder.WriteString("Archive it with `sb-docs fix orphans`, or rename it with `sb-docs migrate-role` if the role was renamed.\n")
+	}
+
+	builder.WriteString("\n---\n")
+	if m.workflowURL != "" {
+		builder.WriteString(fmt.Sprintf("**Workflow run:** [link](%s)\n", m.workflowURL))
+	}
+	builder.WriteString("*This issue is automatically managed by docs-automation and closed once the problem is resolved*\n")
+	builder.WriteString(fmt.Sprintf("<!-- sb-docs-fingerprint: %s -->\n", item.fingerprint()))
+
+	return builder.String()
+}
+
+// ManageItemIssues keeps one issue per missing doc, missing variables
+// section and orphaned doc, labelled ItemLabel(label): issues are opened for
+// new problems and commented on and closed once their problem is resolved.
+// The tracking issue labelled label links all of them and lists the
+// remaining problems.
+func (m *IssueManager) ManageItemIssues(result *CheckResult, label string) error {
+	children, err := m.syncItemIssues(result, ItemLabel(label))
+	if err != nil {
+		return err
+	}
+	return m.syncTrackingIssue(result, label, children)
+}
+
+// syncItemIssues opens and closes the per-problem issues and returns the
+// issue number of each current problem by fingerprint.
+func (m *IssueManager) syncItemIssues(result *CheckResult, label string) (map[string]int, error) {
+	open, err := m.forge.ListIssues(label)
+	if err != nil {
+		return nil, fmt.Errorf("listing item issues: %w", err)
+	}
+
+	// Index open issues by fingerprint, closing duplicates
+	existing := make(map[string]int)
+	var stale []*Issue
+	for _, issue := range open {
+		fingerprint := IssueFingerprint(issue.Body)
+		if fingerprint == "" {
+			// Not created by docs-automation
+			continue
+		}
+		if number, ok := existing[fingerprint]; ok {
+			if err := m.forge.AddComment(issue.Number, fmt.Sprintf("Duplicate of #%d. Closing this issue.", number)); err != nil {
+				fmt.Printf("Note: could not add duplicate comment: %v\n", err)
+			}
+			if err := m.forge.CloseIssue(issue.Number); err != nil {
+				return nil, fmt.Errorf("closing duplicate issue #%d: %w", issue.Number, err)
+			}
+			fmt.Printf("Closed duplicate issue #%d\n", issue.Number)
+			continue
+		}
+		existing[fingerprint] = issue.Number
+		stale = append(stale, issue)
+	}
+
+	// Open issues for new problems; existing ones are left untouched
+	children := make(map[string]int)
+	for _, item := range issueItems(result) {
+		fingerprint := item.fingerprint()
+		if number, ok := existing[fingerprint]; ok {
+			children[fingerprint] = number
+			continue
+		}
+		issue, err := m.forge.CreateIssue(itemTitle(item), m.itemBody(item), label)
+		if err != nil {
+			return nil, fmt.Errorf("creating issue for %s: %w", fingerprint, err)
+		}
+		fmt.Printf("Created issue #%d for %s\n", issue.Number, fingerprint)
+		children[fingerprint] = issue.Number
+	}
+
+	// Close the issues of resolved problems
+	for _, issue := range stale {
+		if _, ok := children[IssueFingerprint(issue.Body)]; ok {
+			continue
+		}
+		if err := m.forge.AddComment(issue.Number, "✅ Resolved: no longer reported by the documentation checks. Closing this issue."); err != nil {
+			fmt.Printf("Note: could not add closing comment: %v\n", err)
+		}
+		if err := m.forge.CloseIssue(issue.Number); err != nil {
+			return nil, fmt.Errorf("closing issue #%d: %w", issue.Number, err)
+		}
+		fmt.Printf("Closed issue #%d\n", issue.Number)
+	}
+
+	return children, nil
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIssueFingerprint(t *testing.T) {
+	body := "Text\n<!-- sb-docs-fingerprint: missing-sections:docs/apps/plex.md -->\n"
+	if got := IssueFingerprint(body); got != Fingerprint(KindMissingSections, "docs/apps/plex.md") {
+		t.Errorf("IssueFingerprint = %q", got)
+	}
+	if got := IssueFingerprint("no fingerprint"); got != "" {
+		t.Errorf("IssueFingerprint without comment = %q, want empty", got)
+	}
+}
+
+func TestManageItemIssues(t *testing.T) {
+	fake, server := newFakeGitHub(t, "")
+	manager := NewIssueManager(NewGitHubForge(NewRESTClient("owner/repo", "test-token", server.URL), "https://github.com", "owner/repo"), "")
+
+	result := &CheckResult{
+		MissingDocs:        []string{"plex"},
+		OrphanedDocs:       []string{"docs/apps/old.md"},
+		InvalidInstallTags: []string{"docs/apps/sonarr.md:3: sonar"},
+	}
+	if err := manager.ManageItemIssues(result, "docs-automation"); err != nil {
+		t.Fatalf("first run: %v", err)
+	}
+
+	// Two item issues, then the parent linking them
+	if len(fake.issues) != 3 {
+		t.Fatalf("after first run: %d issues, want 3", len(fake.issues))
+	}
+	plex, old, parent := fake.issues[0], fake.issues[1], fake.issues[2]
+	if plex.Labels[0] != "docs-automation-item" || IssueFingerprint(plex.Body) != "missing-docs:plex" {
+		t.Errorf("plex issue: labels=%v body=\n%s", plex.Labels, plex.Body)
+	}
+	if IssueFingerprint(old.Body) != "orphaned-docs:docs/apps/old.md" {
+		t.Errorf("orphan issue body:\n%s", old.Body)
+	}
+	for _, line := range []string{"- [ ] #1 `plex`", "- [ ] #2 `docs/apps/old.md`", "- [ ] `docs/apps/sonarr.md:3: sonar`"} {
+		if !strings.Contains(parent.Body, line) {
+			t.Errorf("parent body does not contain %q:\n%s", line, parent.Body)
+		}
+	}
+	if parent.Labels[0] != "docs-automation" || !fake.pinned[parent.NodeID] {
+		t.Errorf("parent issue: labels=%v pinned=%v", parent.Labels, fake.pinned)
+	}
+
+	// The orphan is resolved and a new doc goes missing
+	result = &CheckResult{MissingDocs: []string{"plex", "sonarr"}}
+	if err := manager.ManageItemIssues(result, "docs-automation"); err != nil {
+		t.Fatalf("second run: %v", err)
+	}
+	if len(fake.issues) != 4 || IssueFingerprint(fake.issues[3].Body) != "missing-docs:sonarr" {
+		t.Fatalf("after second run: %+v", fake.issues)
+	}
+	if plex.State != StateOpen || old.State != StateClosed || len(fake.comments[2]) != 1 || len(fake.comments[1]) != 0 {
+		t.Errorf("after second run: plex=%s old=%s comments=%v", plex.State, old.State, fake.comments)
+	}
+	if !strings.Contains(parent.Body, "- [ ] #4 `sonarr`") || strings.Contains(parent.Body, "old.md") {
+		t.Errorf("parent body after second run:\n%s", parent.Body)
+	}
+
+	// Everything resolved: all issues closed
+	if err := manager.ManageItemIssues(&CheckResult{}, "docs-automation"); err != nil {
+		t.Fatalf("third run: %v", err)
+	}
+	for _, issue := range fake.issues {
+		if issue.State != StateClosed {
+			t.Errorf("issue #%d still open", issue.Number)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PRReportMarker identifies the docs impact comment on a pull request.
+const PRReportMarker = "<!-- sb-docs-pr-report -->"
+
+// maxCommentSize keeps comments below GitHub's 65536 character limit.
+const maxCommentSize = 60000
+
+// PullRequestEvent is the part of a pull_request workflow event payload
+// used to comment on the pull request.
+type PullRequestEvent struct {
+	Number int    // Pull request number
+	Repo   string // Base repository in format "owner/repo"
+}
+
+// LoadPullRequestEvent reads a pull_request (or pull_request_target) event
+// payload, e.g. from GITHUB_EVENT_PATH.
+func LoadPullRequestEvent(path string) (*PullRequestEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading event: %w", err)
+	}
+
+	var payload struct {
+		PullRequest *struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("parsing event: %w", err)
+	}
+	if payload.PullRequest == nil {
+		return nil, fmt.Errorf("event %s is not a pull request event", path)
+	}
+
+	return &PullRequestEvent{Number: payload.PullRequest.Number, Repo: payload.Repository.FullName}, nil
+}
+
+// RoleImpact is the change a pull request makes to the generated
+// documentation of one role.
+type RoleImpact struct {
+	Name     string
+	RepoType string   // "saltbox" or "sandbox"
+	Doc      string   // Doc path relative to the docs repo
+	Added    []string // Variables added to the variables section
+	Removed  []string // Variables removed from the variables section
+	Changed  []string // Variables whose rendered value changed
+	Diff     string   // Unified diff of the doc
+}
+
+// GeneratePRReport generates the markdown of the docs impact comment.
+// Diffs that would take the comment over the size limit are left out.
+// failed lists the roles that could not be rendered.
+func GeneratePRReport(impacts []RoleImpact, failed []RoleResult) string {
+	var sb strings.Builder
+
+	sb.WriteString(PRReportMarker + "\n")
+	sb.WriteString("## 📚 Documentation Impact\n\n")
+
+	if len(impacts) == 0 {
+		sb.WriteString("This pull request does not change the generated documentation.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("This pull request changes the generated documentation of %d role(s). The docs are updated once it is merged.\n\n", len(impacts)))
+
+		sb.WriteString("| Role | Repository | Added | Removed | Changed |\n")
+		sb.WriteString("|------|------------|-------|---------|---------|\n")
+		for _, impact := range impacts {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %d |\n", impact.Name, impact.RepoType, len(impact.Added), len(impact.Removed), len(impact.Changed)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(failed) > 0 {
+		sb.WriteString(fmt.Sprintf("### ⚠️ Roles That Could Not Be Rendered (%d)\n\n", len(failed)))
+		for _, r := range failed {
+			sb.WriteString(fmt.Sprintf("- `%s` (%s): %s\n", r.Name, r.RepoType, r.Error))
+		}
+		sb.WriteString("\n")
+	}
+
+	omitted := 0
+	for _, impact := range impacts {
+		var section strings.Builder
+		section.WriteString(fmt.Sprintf("### %s\n\n", impact.Name))
+		writeVariableList(&section, "Added", impact.Added)
+		writeVariableList(&section, "Removed", impact.Removed)
+		writeVariableList(&section, "Changed", impact.Changed)
+		if len(impact.Added)+len(impact.Removed)+len(impact.Changed) == 0 {
+			section.WriteString("No variable changes; other generated content changed.\n\n")
+		}
+
+		diff := fmt.Sprintf("<details>\n<summary>Diff of <code>%s</code></summary>\n\n%s\n</details>\n\n", impact.Doc, diffBlock(impact.Diff))
+		if sb.Len()+section.Len()+len(diff) > maxCommentSize {
+			diff = ""
+			omitted++
+		}
+		if sb.Len()+section.Len() > maxCommentSize {
+			continue
+		}
+		sb.WriteString(section.String())
+		sb.WriteString(diff)
+	}
+
+	if omitted > 0 {
+		sb.WriteString(fmt.Sprintf("*%d diff(s) omitted to stay within the comment size limit; run `sb-docs pr-report --dry-run` locally to see them.*\n\n", omitted))
+	}
+
+	sb.WriteString("---\n")
+	sb.WriteString("*This comment is automatically updated by docs-automation*\n")
+
+	return sb.String()
+}
+
+// diffBlock returns a diff as a fenced code block. The fence is longer than
+// any backtick run in the diff, so code blocks of the doc do not end it.
+func diffBlock(diff string) string {
+	longest, run := 0, 0
+	for _, c := range diff {
+		if c == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fmt.Sprintf("%sdiff\n%s%s\n", fence, diff, fence)
+}
+
+// writeVariableList writes a labelled list of variable names, if any.
+func writeVariableList(sb *strings.Builder, label string, names []string) {
+	if len(names) == 0 {
+		return
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "`" + name + "`"
+	}
+	sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", label, strings.Join(quoted, ", ")))
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadPullRequestEvent(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "event.json")
+	if err := os.WriteFile(path, []byte(`{"action":"synchronize","number":12,"pull_request":{"number":12},"repository":{"full_name":"saltyorg/Saltbox"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	event, err := LoadPullRequestEvent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Number != 12 || event.Repo != "saltyorg/Saltbox" {
+		t.Errorf("event = %+v", event)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"ref":"refs/heads/master","repository":{"full_name":"saltyorg/Saltbox"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPullRequestEvent(path); err == nil {
+		t.Error("expected an error for a push event")
+	}
+}
+
+func TestGeneratePRReport(t *testing.T) {
+	impacts := []RoleImpact{
+		{Name: "plex", RepoType: "saltbox", Doc: "docs/apps/plex.md", Added: []string{"plex_role_new"}, Diff: "+plex_role_new: true\n"},
+		{Name: "big", RepoType: "sandbox", Doc: "docs/sandbox/apps/big.md", Diff: strings.Repeat("+line\n", maxCommentSize/6)},
+	}
+	body := GeneratePRReport(impacts, []RoleResult{{Name: "broken", RepoType: "saltbox", Error: "parsing: bad yaml"}})
+
+	for _, expected := range []string{
+		PRReportMarker,
+		"| plex | saltbox | 1 | 0 | 0 |",
+		"**Added:** `plex_role_new`",
+		"<summary>Diff of <code>docs/apps/plex.md</code></summary>",
+		"- `broken` (saltbox): parsing: bad yaml",
+		"### big\n\nNo variable changes",
+		"*1 diff(s) omitted",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("report does not contain %q:\n%s", expected, body)
+		}
+	}
+	if len(body) > maxCommentSize {
+		t.Errorf("report has %d characters, over the %d limit", len(body), maxCommentSize)
+	}
+
+	if body := GeneratePRReport(nil, nil); !strings.Contains(body, "does not change the generated documentation") {
+		t.Errorf("empty report:\n%s", body)
+	}
+}
+
+func TestRESTClient_Comments(t *testing.T) {
+	requests, server := recordingServer(t, map[string]string{
+		"GET /repos/owner/repo/issues/3/comments":   `[{"id": 5, "body": "LGTM"}, {"id": 7, "body": "` + PRReportMarker + `\nold"}]`,
+		"PATCH /repos/owner/repo/issues/comments/7": `{}`,
+	})
+	client := NewRESTClient("owner/repo", "token", server.URL)
+
+	comment, err := client.FindComment(3, PRReportMarker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment == nil || comment.ID != 7 {
+		t.Fatalf("FindComment = %+v", comment)
+	}
+	if err := client.UpdateComment(comment.ID, "new"); err != nil {
+		t.Fatal(err)
+	}
+	if last := (*requests)[len(*requests)-1]; last != `PATCH /repos/owner/repo/issues/comments/7 {"body":"new"}` {
+		t.Errorf("last request = %s", last)
+	}
+}
+
+func TestDiffBlock(t *testing.T) {
+	if got, want := diffBlock("-a\n+b\n"), "```diff\n-a\n+b\n```\n"; got != want {
+		t.Errorf("diffBlock = %q, want %q", got, want)
+	}
+	if got, want := diffBlock(" ```yaml\n-a: 1\n+a: 2\n ```\n"), "````diff\n ```yaml\n-a: 1\n+a: 2\n ```\n````\n"; got != want {
+		t.Errorf("diffBlock with code fences = %q, want %q", got, want)
+	}
+}
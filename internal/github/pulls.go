@@ -0,0 +1,88 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PullRequest is a pull request (merge request on GitLab).
+type PullRequest struct {
+	Number int
+	URL    string
+}
+
+// PullRequestClient manages the pull requests of one repository.
+type PullRequestClient interface {
+	// FindPullRequest returns the open pull request from head into base,
+	// or nil if there is none.
+	FindPullRequest(head, base string) (*PullRequest, error)
+	CreatePullRequest(head, base, title, body string) (*PullRequest, error)
+	UpdatePullRequest(number int, title, body string) error
+}
+
+// maxPullRequestBody keeps pull request bodies below GitHub's 65536 character limit.
+const maxPullRequestBody = 60000
+
+// UpsertPullRequest opens a pull request from head into base, or updates
+// the title and body of the one already open. Bodies over the size limit
+// are truncated. It reports whether the pull request was created.
+func UpsertPullRequest(client PullRequestClient, head, base, title, body string) (*PullRequest, bool, error) {
+	if len(body) > maxPullRequestBody {
+		// Cut at a line end to keep the markdown intact
+		cut := strings.LastIndex(body[:maxPullRequestBody], "\n")
+		body = body[:cut+1] + "\n*Truncated: see the workflow run for the full summary.*\n"
+	}
+
+	existing, err := client.FindPullRequest(head, base)
+	if err != nil {
+		return nil, false, fmt.Errorf("finding pull request: %w", err)
+	}
+	if existing != nil {
+		if err := client.UpdatePullRequest(existing.Number, title, body); err != nil {
+			return nil, false, fmt.Errorf("updating pull request #%d: %w", existing.Number, err)
+		}
+		return existing, false, nil
+	}
+
+	created, err := client.CreatePullRequest(head, base, title, body)
+	if err != nil {
+		return nil, false, fmt.Errorf("creating pull request: %w", err)
+	}
+	return created, true, nil
+}
+
+// pullRequests returns the pull request client of the issue backend.
+func (f *GitHubForge) pullRequests() (PullRequestClient, error) {
+	client, ok := f.IssueClient.(PullRequestClient)
+	if !ok {
+		return nil, fmt.Errorf("issue backend %T does not support pull requests", f.IssueClient)
+	}
+	return client, nil
+}
+
+// FindPullRequest returns the open pull request from head into base.
+func (f *GitHubForge) FindPullRequest(head, base string) (*PullRequest, error) {
+	client, err := f.pullRequests()
+	if err != nil {
+		return nil, err
+	}
+	return client.FindPullRequest(head, base)
+}
+
+// CreatePullRequest opens a pull request from head into base.
+func (f *GitHubForge) CreatePullRequest(head, base, title, body string) (*PullRequest, error) {
+	client, err := f.pullRequests()
+	if err != nil {
+		return nil, err
+	}
+	return client.CreatePullRequest(head, base, title, body)
+}
+
+// UpdatePullRequest updates the title and body of a pull request.
+func (f *GitHubForge) UpdatePullRequest(number int, title, body string) error {
+	client, err := f.pullRequests()
+	if err != nil {
+		return err
+	}
+	return client.UpdatePullRequest(number, title, body)
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUpsertPullRequest_REST(t *testing.T) {
+	requests, server := recordingServer(t, map[string]string{
+		"GET /repos/owner/docs/pulls":  `[]`,
+		"POST /repos/owner/docs/pulls": `{"number": 4, "html_url": "https://github.com/owner/docs/pull/4"}`,
+	})
+	client := NewRESTClient("owner/docs", "token", server.URL)
+
+	pr, created, err := UpsertPullRequest(client, "sb-docs/update", "master", "Update plex documentation", "Body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !created || pr.Number != 4 || pr.URL != "https://github.com/owner/docs/pull/4" {
+		t.Errorf("UpsertPullRequest = %+v, %v", pr, created)
+	}
+	expected := []string{
+		"GET /repos/owner/docs/pulls",
+		`POST /repos/owner/docs/pulls {"base":"master","body":"Body","head":"sb-docs/update","title":"Update plex documentation"}`,
+	}
+	if !reflect.DeepEqual(*requests, expected) {
+		t.Errorf("requests = %v, want %v", *requests, expected)
+	}
+}
+
+func TestUpsertPullRequest_Update(t *testing.T) {
+	requests, server := recordingServer(t, map[string]string{
+		"GET /api/v4/projects/group%2Fdocs/merge_requests":   `[{"iid": 9, "web_url": "https://gitlab.com/group/docs/-/merge_requests/9"}]`,
+		"PUT /api/v4/projects/group%2Fdocs/merge_requests/9": `{}`,
+	})
+	client := NewGitLabClient("group/docs", "token", "https://gitlab.com", server.URL+"/api/v4")
+
+	body := strings.Repeat("| role | saltbox |\n", maxPullRequestBody/10)
+	pr, created, err := UpsertPullRequest(client, "sb-docs/update", "main", "Update documentation of 2 roles", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || pr.Number != 9 {
+		t.Errorf("UpsertPullRequest = %+v, %v", pr, created)
+	}
+	last := (*requests)[len(*requests)-1]
+	if !strings.HasPrefix(last, `PUT /api/v4/projects/group%2Fdocs/merge_requests/9 {"description":"| role | saltbox |\n`) ||
+		!strings.Contains(last, `|\n\n*Truncated`) {
+		t.Errorf("update request = %.200s...", last)
+	}
+}
+
+func TestGiteaClient_FindPullRequest(t *testing.T) {
+	_, server := recordingServer(t, map[string]string{
+		"GET /api/v1/repos/saltyorg/docs/pulls": `[
+			{"number": 2, "html_url": "u2", "head": {"ref": "feature"}, "base": {"ref": "main"}},
+			{"number": 3, "html_url": "u3", "head": {"ref": "sb-docs/update"}, "base": {"ref": "main"}}
+		]`,
+	})
+	client := NewGiteaClient("saltyorg/docs", "token", "https://git.example.com", server.URL+"/api/v1")
+
+	pr, err := client.FindPullRequest("sb-docs/update", "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr == nil || pr.Number != 3 || pr.URL != "u3" {
+		t.Errorf("FindPullRequest = %+v", pr)
+	}
+	if pr, err := client.FindPullRequest("sb-docs/update", "develop"); err != nil || pr != nil {
+		t.Errorf("FindPullRequest into develop = %+v, %v", pr, err)
+	}
+}
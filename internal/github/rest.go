@@ -0,0 +1,262 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RESTClient is an IssueClient backed by the GitHub REST API, with GraphQL
+// for pinning. It works against github.com and GitHub Enterprise Server.
+type RESTClient struct {
+	BaseURL    string       // REST API base URL, e.g. https://api.github.com or https://ghes.example.com/api/v3
+	GraphQLURL string       // GraphQL endpoint, derived from BaseURL when empty
+	Token      string       // Token sent as a bearer token
+	Client     *http.Client // HTTP client, a 30s timeout client when nil
+	repo       string       // Repository in format "owner/repo"
+}
+
+// NewRESTClient returns a REST API issue client for repo.
+func NewRESTClient(repo, token, baseURL string) *RESTClient {
+	return &RESTClient{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Token:   token,
+		repo:    repo,
+	}
+}
+
+// graphQLURL returns the GraphQL endpoint: <host>/graphql for github.com,
+// <host>/api/graphql for GHES (REST under <host>/api/v3).
+func (c *RESTClient) graphQLURL() string {
+	if c.GraphQLURL != "" {
+		return c.GraphQLURL
+	}
+	if base, ok := strings.CutSuffix(c.BaseURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return c.BaseURL + "/graphql"
+}
+
+// restIssue is an issue in REST API responses.
+type restIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	NodeID      string    `json:"node_id"`
+	PullRequest *struct{} `json:"pull_request"` // Set for pull requests
+}
+
+func (i restIssue) issue() *Issue {
+	return &Issue{Number: i.Number, Title: i.Title, Body: i.Body, State: i.State, NodeID: i.NodeID}
+}
+
+// do sends a JSON request to the REST or GraphQL API.
+func (c *RESTClient) do(method, endpoint string, in, out any) error {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return doJSON(c.Client, header, method, endpoint, in, out)
+}
+
+// issuesURL returns the URL of the issues collection, or of one issue's subresource.
+func (c *RESTClient) issuesURL(parts ...string) string {
+	endpoint := fmt.Sprintf("%s/repos/%s/issues", c.BaseURL, c.repo)
+	for _, part := range parts {
+		endpoint += "/" + part
+	}
+	return endpoint
+}
+
+// FindIssue finds the most recently created issue with the given label.
+// Pull requests, which the issues endpoint also returns, are skipped.
+func (c *RESTClient) FindIssue(label string) (*Issue, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "all")
+	query.Set("sort", "created")
+	query.Set("direction", "desc")
+	query.Set("per_page", "30")
+
+	var issues []restIssue
+	if err := c.do(http.MethodGet, c.issuesURL()+"?"+query.Encode(), nil, &issues); err != nil {
+		return nil, err
+	}
+	for _, issue := range issues {
+		if issue.PullRequest == nil {
+			return issue.issue(), nil
+		}
+	}
+	return nil, nil
+}
+
+// ListIssues returns all open issues with the label, skipping pull requests.
+func (c *RESTClient) ListIssues(label string) ([]*Issue, error) {
+	query := url.Values{}
+	query.Set("labels", label)
+	query.Set("state", "open")
+	query.Set("per_page", "100")
+
+	var result []*Issue
+	for page := 1; ; page++ {
+		query.Set("page", fmt.Sprint(page))
+		var issues []restIssue
+		if err := c.do(http.MethodGet, c.issuesURL()+"?"+query.Encode(), nil, &issues); err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if issue.PullRequest == nil {
+				result = append(result, issue.issue())
+			}
+		}
+		if len(issues) < 100 {
+			return result, nil
+		}
+	}
+}
+
+// CreateIssue creates a new issue.
+func (c *RESTClient) CreateIssue(title, body, label string) (*Issue, error) {
+	in := map[string]any{"title": title, "body": body, "labels": []string{label}}
+	var created restIssue
+	if err := c.do(http.MethodPost, c.issuesURL(), in, &created); err != nil {
+		return nil, err
+	}
+	return created.issue(), nil
+}
+
+// UpdateIssue updates the title and body of an issue.
+func (c *RESTClient) UpdateIssue(number int, title, body string) error {
+	in := map[string]string{"title": title, "body": body}
+	return c.do(http.MethodPatch, c.issuesURL(fmt.Sprint(number)), in, nil)
+}
+
+// CloseIssue closes an issue.
+func (c *RESTClient) CloseIssue(number int) error {
+	return c.do(http.MethodPatch, c.issuesURL(fmt.Sprint(number)), map[string]string{"state": StateClosed}, nil)
+}
+
+// ReopenIssue reopens a closed issue.
+func (c *RESTClient) ReopenIssue(number int) error {
+	return c.do(http.MethodPatch, c.issuesURL(fmt.Sprint(number)), map[string]string{"state": StateOpen}, nil)
+}
+
+// AddComment adds a comment to an issue.
+func (c *RESTClient) AddComment(number int, body string) error {
+	return c.do(http.MethodPost, c.issuesURL(fmt.Sprint(number), "comments"), map[string]string{"body": body}, nil)
+}
+
+// FindComment returns the first comment of an issue or pull request that
+// contains marker, or nil if there is none.
+func (c *RESTClient) FindComment(number int, marker string) (*Comment, error) {
+	for page := 1; ; page++ {
+		var comments []struct {
+			ID   int64  `json:"id"`
+			Body string `json:"body"`
+		}
+		endpoint := fmt.Sprintf("%s?per_page=100&page=%d", c.issuesURL(fmt.Sprint(number), "comments"), page)
+		if err := c.do(http.MethodGet, endpoint, nil, &comments); err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, marker) {
+				return &Comment{ID: comment.ID, Body: comment.Body}, nil
+			}
+		}
+		if len(comments) < 100 {
+			return nil, nil
+		}
+	}
+}
+
+// UpdateComment replaces the body of a comment.
+func (c *RESTClient) UpdateComment(id int64, body string) error {
+	return c.do(http.MethodPatch, c.issuesURL("comments", fmt.Sprint(id)), map[string]string{"body": body}, nil)
+}
+
+// PinIssue pins an issue to the repository through GraphQL.
+func (c *RESTClient) PinIssue(issue *Issue) error {
+	return c.pinMutation("pinIssue", issue)
+}
+
+// UnpinIssue unpins an issue from the repository through GraphQL.
+func (c *RESTClient) UnpinIssue(issue *Issue) error {
+	return c.pinMutation("unpinIssue", issue)
+}
+
+// pinMutation runs the pinIssue or unpinIssue GraphQL mutation.
+func (c *RESTClient) pinMutation(mutation string, issue *Issue) error {
+	if issue.NodeID == "" {
+		return fmt.Errorf("issue #%d has no node ID", issue.Number)
+	}
+	in := map[string]any{
+		"query":     fmt.Sprintf("mutation($id: ID!) { %s(input: {issueId: $id}) { issue { number } } }", mutation),
+		"variables": map[string]string{"id": issue.NodeID},
+	}
+	var out struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := c.do(http.MethodPost, c.graphQLURL(), in, &out); err != nil {
+		return err
+	}
+	if len(out.Errors) > 0 {
+		return fmt.Errorf("%s: %s", mutation, out.Errors[0].Message)
+	}
+	return nil
+}
+
+// restPullRequest is a pull request in REST API responses.
+type restPullRequest struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// pullsURL returns the URL of the pull requests collection, or of one pull request.
+func (c *RESTClient) pullsURL(parts ...string) string {
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls", c.BaseURL, c.repo)
+	for _, part := range parts {
+		endpoint += "/" + part
+	}
+	return endpoint
+}
+
+// FindPullRequest returns the open pull request from head (a branch of the
+// repository) into base, or nil.
+func (c *RESTClient) FindPullRequest(head, base string) (*PullRequest, error) {
+	owner, _, _ := strings.Cut(c.repo, "/")
+	query := url.Values{}
+	query.Set("state", "open")
+	query.Set("head", owner+":"+head)
+	query.Set("base", base)
+
+	var pulls []restPullRequest
+	if err := c.do(http.MethodGet, c.pullsURL()+"?"+query.Encode(), nil, &pulls); err != nil {
+		return nil, err
+	}
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+	return &PullRequest{Number: pulls[0].Number, URL: pulls[0].HTMLURL}, nil
+}
+
+// CreatePullRequest opens a pull request from head into base.
+func (c *RESTClient) CreatePullRequest(head, base, title, body string) (*PullRequest, error) {
+	in := map[string]string{"title": title, "head": head, "base": base, "body": body}
+	var created restPullRequest
+	if err := c.do(http.MethodPost, c.pullsURL(), in, &created); err != nil {
+		return nil, err
+	}
+	return &PullRequest{Number: created.Number, URL: created.HTMLURL}, nil
+}
+
+// UpdatePullRequest updates the title and body of a pull request.
+func (c *RESTClient) UpdatePullRequest(number int, title, body string) error {
+	in := map[string]string{"title": title, "body": body}
+	return c.do(http.MethodPatch, c.pullsURL(fmt.Sprint(number)), in, nil)
+}
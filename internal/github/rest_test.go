@@ -0,0 +1,215 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeGitHub is an in-memory GitHub issues API.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	issues   []*fakeIssue
+	comments map[int][]string
+	pinned   map[string]bool // node IDs
+	requests []string        // "METHOD path" of every request
+}
+
+type fakeIssue struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	State  string   `json:"state"`
+	NodeID string   `json:"node_id"`
+	Labels []string `json:"-"`
+}
+
+// newFakeGitHub starts a fake API serving owner/repo under prefix (e.g. "/api/v3").
+func newFakeGitHub(t *testing.T, prefix string) (*fakeGitHub, *httptest.Server) {
+	f := &fakeGitHub{comments: make(map[int][]string), pinned: make(map[string]bool)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Bad credentials"}`)
+			return
+		}
+
+		var in map[string]any
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&in)
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+		switch {
+		case path == "/repos/owner/repo/issues" && r.Method == http.MethodGet:
+			query := r.URL.Query()
+			label, state := query.Get("labels"), query.Get("state")
+			result := []any{map[string]any{"number": 99, "state": "open", "pull_request": map[string]any{}}}
+			if page := query.Get("page"); page != "" && page != "1" {
+				result = nil
+			}
+			for i := len(f.issues) - 1; i >= 0 && result != nil; i-- {
+				if state != "all" && f.issues[i].State != state {
+					continue
+				}
+				for _, l := range f.issues[i].Labels {
+					if l == label {
+						result = append(result, f.issues[i])
+					}
+				}
+			}
+			_ = json.NewEncoder(w).Encode(result)
+
+		case path == "/repos/owner/repo/issues" && r.Method == http.MethodPost:
+			issue := &fakeIssue{
+				Number: len(f.issues) + 1,
+				Title:  in["title"].(string),
+				Body:   in["body"].(string),
+				State:  StateOpen,
+				NodeID: fmt.Sprintf("I_%d", len(f.issues)+1),
+			}
+			for _, l := range in["labels"].([]any) {
+				issue.Labels = append(issue.Labels, l.(string))
+			}
+			f.issues = append(f.issues, issue)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(issue)
+
+		case strings.HasPrefix(path, "/repos/owner/repo/issues/"):
+			rest := strings.TrimPrefix(path, "/repos/owner/repo/issues/")
+			numStr, sub, _ := strings.Cut(rest, "/")
+			num, _ := strconv.Atoi(numStr)
+			if num < 1 || num > len(f.issues) {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message":"Not Found"}`)
+				return
+			}
+			issue := f.issues[num-1]
+			if sub == "comments" {
+				f.comments[num] = append(f.comments[num], in["body"].(string))
+				w.WriteHeader(http.StatusCreated)
+				fmt.Fprint(w, `{}`)
+				return
+			}
+			for key, value := range in {
+				switch key {
+				case "title":
+					issue.Title = value.(string)
+				case "body":
+					issue.Body = value.(string)
+				case "state":
+					issue.State = value.(string)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(issue)
+
+		case path == "/graphql" || path == "/api/graphql":
+			query := in["query"].(string)
+			id := in["variables"].(map[string]any)["id"].(string)
+			f.pinned[id] = strings.Contains(query, "{ pinIssue(")
+			fmt.Fprint(w, `{"data":{}}`)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"Not Found"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func TestManageIssue_REST(t *testing.T) {
+	fake, server := newFakeGitHub(t, "")
+	manager := NewIssueManager(NewGitHubForge(NewRESTClient("owner/repo", "test-token", server.URL), "https://github.com", "owner/repo"), "")
+
+	failing := &CheckResult{MissingDocs: []string{"plex"}}
+	if err := manager.ManageIssue(failing, "docs-automation"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if len(fake.issues) != 1 || fake.issues[0].State != StateOpen || !fake.pinned["I_1"] {
+		t.Fatalf("after create: issues=%+v pinned=%v", fake.issues, fake.pinned)
+	}
+	if !strings.Contains(fake.issues[0].Body, "`plex`") {
+		t.Errorf("issue body does not list plex:\n%s", fake.issues[0].Body)
+	}
+
+	failing.MissingDocs = append(failing.MissingDocs, "sonarr")
+	if err := manager.ManageIssue(failing, "docs-automation"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if len(fake.issues) != 1 || fake.issues[0].Title != "[Docs Automation] 2 documentation issues found" {
+		t.Fatalf("after update: issues=%+v", fake.issues)
+	}
+	if len(fake.comments[1]) != 1 || fake.comments[1][0] != "**Changes since the last run:** +1 missing doc: `sonarr`" {
+		t.Errorf("after update: comments=%v", fake.comments)
+	}
+
+	// An unchanged run writes nothing
+	requests := len(fake.requests)
+	if err := manager.ManageIssue(failing, "docs-automation"); err != nil {
+		t.Fatalf("unchanged: %v", err)
+	}
+	if len(fake.requests) != requests+1 {
+		t.Errorf("unchanged run sent %v after the lookup", fake.requests[requests+1:])
+	}
+
+	if err := manager.ManageIssue(&CheckResult{}, "docs-automation"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if fake.issues[0].State != StateClosed || fake.pinned["I_1"] || len(fake.comments[1]) != 2 {
+		t.Fatalf("after close: issue=%+v pinned=%v comments=%v", fake.issues[0], fake.pinned, fake.comments)
+	}
+
+	// A closed issue is reopened when problems come back
+	if err := manager.ManageIssue(failing, "docs-automation"); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if len(fake.issues) != 1 || fake.issues[0].State != StateOpen {
+		t.Fatalf("after reopen: issues=%+v", fake.issues)
+	}
+}
+
+func TestRESTClient_GHES(t *testing.T) {
+	fake, server := newFakeGitHub(t, "/api/v3")
+	client := NewRESTClient("owner/repo", "test-token", server.URL+"/api/v3/")
+
+	issue, err := client.CreateIssue("Title", "Body", "docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.PinIssue(issue); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"POST /api/v3/repos/owner/repo/issues", "POST /api/graphql"}
+	if strings.Join(fake.requests, ",") != strings.Join(expected, ",") {
+		t.Errorf("requests = %v, want %v", fake.requests, expected)
+	}
+
+	found, err := client.FindIssue("other")
+	if err != nil || found != nil {
+		t.Errorf("FindIssue(other) = %+v, %v; want nil, nil", found, err)
+	}
+}
+
+func TestRESTClient_Errors(t *testing.T) {
+	_, server := newFakeGitHub(t, "")
+
+	_, err := NewRESTClient("owner/repo", "wrong", server.URL).FindIssue("docs")
+	if err == nil || !strings.Contains(err.Error(), "Bad credentials (401)") {
+		t.Errorf("FindIssue with bad token: %v", err)
+	}
+
+	err = NewRESTClient("owner/repo", "test-token", server.URL).UpdateIssue(5, "t", "b")
+	if err == nil || !strings.Contains(err.Error(), "Not Found (404)") {
+		t.Errorf("UpdateIssue of missing issue: %v", err)
+	}
+}
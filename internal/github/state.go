@@ -0,0 +1,134 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// stateRe matches the hidden state block of a tracking issue body.
+var stateRe = regexp.MustCompile(`(?s)<!-- sb-docs-state: (.*?) -->`)
+
+// maxChangeNames is the number of entries listed per change in a comment.
+const maxChangeNames = 10
+
+// problemCategory is one problem list of a CheckResult.
+type problemCategory struct {
+	key      string // Key in the state block
+	singular string // Name of one problem in change comments
+	plural   string // Name of several problems
+	entries  func(*CheckResult) *[]string
+}
+
+// problemCategories lists the problem lists of CheckResult in issue body order.
+var problemCategories = []problemCategory{
+	{"missing_docs", "missing doc", "missing docs", func(r *CheckResult) *[]string { return &r.MissingDocs }},
+	{"missing_sections", "missing variables section", "missing variables sections", func(r *CheckResult) *[]string { return &r.MissingSections }},
+	{"missing_overview_sections", "missing overview section", "missing overview sections", func(r *CheckResult) *[]string { return &r.MissingOverviewSections }},
+	{"orphaned_docs", "orphaned doc", "orphaned docs", func(r *CheckResult) *[]string { return &r.OrphanedDocs }},
+	{"invalid_install_tags", "invalid install tag", "invalid install tags", func(r *CheckResult) *[]string { return &r.InvalidInstallTags }},
+	{"broken_links", "broken link", "broken links", func(r *CheckResult) *[]string { return &r.BrokenLinks }},
+}
+
+// issueState is the state embedded in the tracking issue body, used to
+// tell what changed since the previous run.
+type issueState struct {
+	Problems map[string][]string `json:"problems"`         // Entries by category key
+	Issues   map[string]int      `json:"issues,omitempty"` // Per-role issue numbers by fingerprint
+}
+
+// newIssueState returns the state of a check result and its per-role issues.
+func newIssueState(result *CheckResult, children map[string]int) issueState {
+	state := issueState{Problems: make(map[string][]string), Issues: children}
+	for _, category := range problemCategories {
+		if entries := *category.entries(result); len(entries) > 0 {
+			state.Problems[category.key] = entries
+		}
+	}
+	return state
+}
+
+// result returns the problems of the state as a check result.
+func (s issueState) result() *CheckResult {
+	result := &CheckResult{}
+	for _, category := range problemCategories {
+		*category.entries(result) = s.Problems[category.key]
+	}
+	return result
+}
+
+// stateBlock returns the hidden HTML comment holding the state. JSON
+// encoding escapes "<" and ">", so entries cannot end the comment early.
+func (s issueState) stateBlock() string {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("<!-- sb-docs-state: %s -->\n", data)
+}
+
+// parseIssueState reads the state block of an issue body. It returns false
+// when the body has no valid block, e.g. for issues created by older versions.
+func parseIssueState(body string) (issueState, bool) {
+	var state issueState
+	match := stateRe.FindStringSubmatch(body)
+	if match == nil || json.Unmarshal([]byte(match[1]), &state) != nil {
+		return state, false
+	}
+	return state, true
+}
+
+// unchanged reports whether two states have the same problems and issues.
+func (s issueState) unchanged(other issueState) bool {
+	return describeChanges(s.result(), other.result()) == "" && maps.Equal(s.Issues, other.Issues)
+}
+
+// describeChanges summarizes the problems that appeared and were resolved
+// between two results, e.g. "+2 missing docs: `foo`, `bar`; resolved: `baz`".
+// It returns "" when nothing changed.
+func describeChanges(previous, current *CheckResult) string {
+	var parts, resolved []string
+	for _, category := range problemCategories {
+		before, after := *category.entries(previous), *category.entries(current)
+
+		var added []string
+		for _, entry := range after {
+			if !slices.Contains(before, entry) {
+				added = append(added, entry)
+			}
+		}
+		for _, entry := range before {
+			if !slices.Contains(after, entry) {
+				resolved = append(resolved, entry)
+			}
+		}
+
+		if len(added) > 0 {
+			name := category.plural
+			if len(added) == 1 {
+				name = category.singular
+			}
+			parts = append(parts, fmt.Sprintf("+%d %s: %s", len(added), name, changeNames(added)))
+		}
+	}
+	if len(resolved) > 0 {
+		parts = append(parts, "resolved: "+changeNames(resolved))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// changeNames formats entries as a code span list, truncated to maxChangeNames.
+func changeNames(entries []string) string {
+	var names []string
+	for i, entry := range entries {
+		if i == maxChangeNames {
+			names = append(names, fmt.Sprintf("and %d more", len(entries)-maxChangeNames))
+			break
+		}
+		names = append(names, "`"+entry+"`")
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestIssueStateRoundTrip(t *testing.T) {
+	result := &CheckResult{
+		MissingDocs: []string{"plex"},
+		BrokenLinks: []string{"docs/apps/plex.md:3: https://example.com/--> (404)"},
+	}
+	body := "Body\n" + newIssueState(result, map[string]int{"missing-docs:plex": 4}).stateBlock()
+
+	state, ok := parseIssueState(body)
+	if !ok {
+		t.Fatalf("parseIssueState failed on:\n%s", body)
+	}
+	if !reflect.DeepEqual(state.result(), result) || state.Issues["missing-docs:plex"] != 4 {
+		t.Errorf("parsed state = %+v", state)
+	}
+	if _, ok := parseIssueState("## 📝 Documentation Status\n"); ok {
+		t.Error("parseIssueState accepted a body without state block")
+	}
+}
+
+func TestDescribeChanges(t *testing.T) {
+	var many []string
+	for i := range 12 {
+		many = append(many, fmt.Sprintf("role%d", i))
+	}
+
+	tests := []struct {
+		name     string
+		previous *CheckResult
+		current  *CheckResult
+		expected string
+	}{
+		{
+			name:     "unchanged",
+			previous: &CheckResult{MissingDocs: []string{"plex"}},
+			current:  &CheckResult{MissingDocs: []string{"plex"}},
+			expected: "",
+		},
+		{
+			name:     "added and resolved",
+			previous: &CheckResult{MissingDocs: []string{"baz"}},
+			current:  &CheckResult{MissingDocs: []string{"foo", "bar"}, OrphanedDocs: []string{"docs/apps/old.md"}},
+			expected: "+2 missing docs: `foo`, `bar`; +1 orphaned doc: `docs/apps/old.md`; resolved: `baz`",
+		},
+		{
+			name:     "truncated",
+			previous: &CheckResult{},
+			current:  &CheckResult{MissingSections: many},
+			expected: "+12 missing variables sections: `role0`, `role1`, `role2`, `role3`, `role4`, `role5`, `role6`, `role7`, `role8`, `role9`, and 2 more",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeChanges(tt.previous, tt.current); got != tt.expected {
+				t.Errorf("describeChanges = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
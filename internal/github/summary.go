@@ -24,8 +24,23 @@ type RoleResult struct {
 	SkipReason string     // reason if skipped
 	Error      string     // error message if failed
 	Sections   []string   // which sections were updated (e.g., "variables", "overview")
+	Doc        string     // path of the role's doc file
+
+	// Changes made to the doc, set when the role was updated
+	Added          []string // Variables added to the variables section
+	Removed        []string // Variables removed from the variables section
+	Changed        []string // Variables whose rendered value changed
+	OverviewFields int      // Number of changed overview fields
+	Diff           string   // Unified diff of the doc
 }
 
+// Size limits of the summary markdown. GitHub rejects step summaries over
+// 1 MiB; diffs are left out or truncated to stay below it.
+const (
+	maxStepSummary = 1000000
+	maxSummaryDiff = 65536
+)
+
 // UpdateSummary holds the complete summary of an update run.
 type UpdateSummary struct {
 	Roles       []RoleResult
@@ -69,6 +84,12 @@ func (s *UpdateSummary) SetCheckResult(result *CheckResult) {
 
 // WriteGitHubSummary writes the summary to GITHUB_STEP_SUMMARY if running in GitHub Actions.
 func (s *UpdateSummary) WriteGitHubSummary() error {
+	return AppendStepSummary(s.Markdown())
+}
+
+// AppendStepSummary appends markdown to GITHUB_STEP_SUMMARY if running in
+// GitHub Actions.
+func AppendStepSummary(markdown string) error {
 	// Check if we're running in GitHub Actions
 	if os.Getenv("GITHUB_ACTIONS") != "true" {
 		return nil
@@ -85,6 +106,23 @@ func (s *UpdateSummary) WriteGitHubSummary() error {
 	}
 	defer f.Close()
 
+	_, err = f.WriteString(markdown)
+	return err
+}
+
+// Markdown renders the summary as markdown, as written to the step summary.
+func (s *UpdateSummary) Markdown() string {
+	return s.markdown(maxStepSummary)
+}
+
+// PullRequestBody renders the summary as markdown sized for a pull request body.
+func (s *UpdateSummary) PullRequestBody() string {
+	return s.markdown(maxPullRequestBody)
+}
+
+// markdown renders the summary in at most about limit bytes. The diffs of
+// updated roles come last and are omitted once they no longer fit.
+func (s *UpdateSummary) markdown(limit int) string {
 	var sb strings.Builder
 
 	sb.WriteString("## 📚 Documentation Automation Results\n\n")
@@ -113,14 +151,15 @@ func (s *UpdateSummary) WriteGitHubSummary() error {
 			sb.WriteString(fmt.Sprintf("### Updated Documentation (%d)\n\n", len(updatedRoles)))
 		}
 
-		sb.WriteString("| Role | Repository | Sections |\n")
-		sb.WriteString("|------|------------|----------|\n")
+		sb.WriteString("| Role | Repository | Sections | Added | Removed | Modified | Overview Fields |\n")
+		sb.WriteString("|------|------------|----------|-------|---------|----------|-----------------|\n")
 		for _, r := range updatedRoles {
 			sections := "variables"
 			if len(r.Sections) > 0 {
 				sections = strings.Join(r.Sections, ", ")
 			}
-			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", r.Name, r.RepoType, sections))
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %d | %d | %d | %d |\n",
+				r.Name, r.RepoType, sections, len(r.Added), len(r.Removed), len(r.Changed), r.OverviewFields))
 		}
 		sb.WriteString("\n")
 
@@ -197,10 +236,109 @@ func (s *UpdateSummary) WriteGitHubSummary() error {
 			}
 			sb.WriteString("\n</details>\n\n")
 		}
+
+		if len(s.CheckResult.InvalidInstallTags) > 0 {
+			sb.WriteString(fmt.Sprintf("**Invalid Install Tags:** %d references\n", len(s.CheckResult.InvalidInstallTags)))
+			sb.WriteString("<details>\n<summary>Show references</summary>\n\n")
+			for _, entry := range s.CheckResult.InvalidInstallTags {
+				sb.WriteString(fmt.Sprintf("- `%s`\n", entry))
+			}
+			sb.WriteString("\n</details>\n\n")
+		}
+
+		if len(s.CheckResult.BrokenLinks) > 0 {
+			sb.WriteString(fmt.Sprintf("**Broken Links:** %d links\n", len(s.CheckResult.BrokenLinks)))
+			sb.WriteString("<details>\n<summary>Show links</summary>\n\n")
+			for _, entry := range s.CheckResult.BrokenLinks {
+				sb.WriteString(fmt.Sprintf("- `%s`\n", entry))
+			}
+			sb.WriteString("\n</details>\n\n")
+		}
 	}
 
-	_, err = f.WriteString(sb.String())
-	return err
+	s.writeDiffs(&sb, limit)
+
+	if sb.Len() > limit {
+		// Only reached with huge check results; cut at a line end
+		text := sb.String()
+		cut := strings.LastIndex(text[:limit-100], "\n")
+		return text[:cut+1] + "\n*Truncated: the summary exceeds the size limit.*\n"
+	}
+
+	return sb.String()
+}
+
+// summaryNoteSize is the room kept for the note on omitted diffs.
+const summaryNoteSize = 200
+
+// writeDiffs writes a collapsed diff of each updated role's doc, leaving
+// out diffs that would take the summary over limit.
+func (s *UpdateSummary) writeDiffs(sb *strings.Builder, limit int) {
+	var withDiff []RoleResult
+	for _, r := range s.getRolesByStatus(StatusUpdated) {
+		if r.Diff != "" {
+			withDiff = append(withDiff, r)
+		}
+	}
+	if len(withDiff) == 0 {
+		return
+	}
+
+	sb.WriteString("### Changes\n\n")
+	omitted := 0
+	for _, r := range withDiff {
+		diff, note := r.Diff, ""
+		if len(diff) > maxSummaryDiff {
+			cut := strings.LastIndex(diff[:maxSummaryDiff], "\n")
+			note = fmt.Sprintf("*Diff truncated: %d more lines.*\n\n", strings.Count(diff[cut+1:], "\n"))
+			diff = diff[:cut+1]
+		}
+
+		block := fmt.Sprintf("<details>\n<summary>%s (%s)</summary>\n\n%s\n%s</details>\n\n", r.Name, r.RepoType, diffBlock(diff), note)
+		if sb.Len()+len(block)+summaryNoteSize > limit {
+			omitted++
+			continue
+		}
+		sb.WriteString(block)
+	}
+
+	if omitted > 0 {
+		sb.WriteString(fmt.Sprintf("*%d diff(s) omitted to stay within the size limit.*\n\n", omitted))
+	}
+}
+
+// CommitMessage returns a commit message describing the updated docs: a
+// subject line naming the role (or the number of roles) and a body listing
+// each updated role with its sections.
+func (s *UpdateSummary) CommitMessage() string {
+	updated := s.getRolesByStatus(StatusUpdated)
+
+	var subject string
+	switch {
+	case len(updated) == 1 && !s.CLIUpdated:
+		subject = fmt.Sprintf("Update %s documentation", updated[0].Name)
+	case len(updated) == 1:
+		subject = fmt.Sprintf("Update %s documentation and CLI help", updated[0].Name)
+	case len(updated) == 0 && s.CLIUpdated:
+		subject = "Update CLI help"
+	case s.CLIUpdated:
+		subject = fmt.Sprintf("Update documentation of %d roles and CLI help", len(updated))
+	default:
+		subject = fmt.Sprintf("Update documentation of %d roles", len(updated))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(subject + "\n")
+	if len(updated) > 0 {
+		sb.WriteString("\n")
+		for _, r := range updated {
+			sb.WriteString(fmt.Sprintf("- %s (%s): %s\n", r.Name, r.RepoType, strings.Join(r.Sections, ", ")))
+		}
+		if s.CLIUpdated {
+			sb.WriteString("- CLI help\n")
+		}
+	}
+	return sb.String()
 }
 
 // getRolesByStatus returns all roles with the given status.
@@ -0,0 +1,87 @@
+package github
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommitMessage(t *testing.T) {
+	plex := RoleResult{Name: "plex", RepoType: "saltbox", Status: StatusUpdated, Sections: []string{"variables"}}
+	sonarr := RoleResult{Name: "sonarr", RepoType: "saltbox", Status: StatusUpdated, Sections: []string{"variables", "overview"}}
+	skipped := RoleResult{Name: "radarr", RepoType: "saltbox", Status: StatusSkipped}
+
+	tests := []struct {
+		name       string
+		roles      []RoleResult
+		cliUpdated bool
+		expected   string
+	}{
+		{"one role", []RoleResult{plex, skipped}, false, "Update plex documentation\n\n- plex (saltbox): variables\n"},
+		{"cli only", nil, true, "Update CLI help\n"},
+		{
+			"one role and cli", []RoleResult{plex}, true,
+			"Update plex documentation and CLI help\n\n- plex (saltbox): variables\n- CLI help\n",
+		},
+		{
+			"several roles", []RoleResult{plex, sonarr}, true,
+			"Update documentation of 2 roles and CLI help\n\n- plex (saltbox): variables\n- sonarr (saltbox): variables, overview\n- CLI help\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := NewUpdateSummary()
+			for _, r := range tt.roles {
+				summary.AddRole(r)
+			}
+			summary.CLIUpdated = tt.cliUpdated
+			if got := summary.CommitMessage(); got != tt.expected {
+				t.Errorf("CommitMessage = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownChanges(t *testing.T) {
+	plex := RoleResult{
+		Name: "plex", RepoType: "saltbox", Status: StatusUpdated, Sections: []string{"variables", "overview"},
+		Added: []string{"plex_role_new"}, Changed: []string{"plex_role_web_port", "plex_role_lite"}, OverviewFields: 1,
+		Diff: "--- a/docs/apps/plex.md\n+++ b/docs/apps/plex.md\n@@ -1 +1 @@\n-plex_role_web_port: 1\n+plex_role_web_port: 2\n",
+	}
+	sonarr := RoleResult{
+		Name: "sonarr", RepoType: "saltbox", Status: StatusUpdated, Sections: []string{"variables"},
+		Removed: []string{"sonarr_role_old"},
+		Diff:    "--- a/docs/apps/sonarr.md\n+++ b/docs/apps/sonarr.md\n" + strings.Repeat("+line\n", 20000),
+	}
+
+	summary := NewUpdateSummary()
+	summary.AddRole(plex)
+	summary.AddRole(sonarr)
+
+	markdown := summary.Markdown()
+	for _, want := range []string{
+		"| plex | saltbox | variables, overview | 1 | 0 | 2 | 1 |\n",
+		"| sonarr | saltbox | variables | 0 | 1 | 0 | 0 |\n",
+		"### Changes\n\n<details>\n<summary>plex (saltbox)</summary>\n\n```diff\n--- a/docs/apps/plex.md\n",
+		"+plex_role_web_port: 2\n```\n\n</details>\n",
+		"*Diff truncated: ",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Markdown() does not contain %q", want)
+		}
+	}
+	if len(markdown) > maxStepSummary {
+		t.Errorf("Markdown() is %d bytes, over the %d byte limit", len(markdown), maxStepSummary)
+	}
+
+	// The sonarr diff does not fit a pull request body and is left out
+	body := summary.PullRequestBody()
+	if len(body) > maxPullRequestBody {
+		t.Errorf("PullRequestBody() is %d bytes, over the %d byte limit", len(body), maxPullRequestBody)
+	}
+	if !strings.Contains(body, "<summary>plex (saltbox)</summary>") || strings.Contains(body, "<summary>sonarr (saltbox)</summary>") {
+		t.Error("PullRequestBody() should keep the plex diff and omit the sonarr diff")
+	}
+	if !strings.Contains(body, "*1 diff(s) omitted to stay within the size limit.*") {
+		t.Error("PullRequestBody() does not note the omitted diff")
+	}
+}
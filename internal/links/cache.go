@@ -0,0 +1,94 @@
+package links
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache stores link check results between runs, keyed by URL.
+type Cache struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]Result
+}
+
+// cacheFile is the on-disk format of the cache.
+type cacheFile struct {
+	Results []Result `json:"results"`
+}
+
+// LoadCache reads a cache file. A missing file yields an empty cache.
+// An empty path gives an in-memory cache that is never saved.
+func LoadCache(path string) (*Cache, error) {
+	cache := &Cache{path: path, entries: make(map[string]Result)}
+	if path == "" {
+		return cache, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, err
+	}
+
+	var file cacheFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	for _, result := range file.Results {
+		cache.entries[result.URL] = result
+	}
+	return cache, nil
+}
+
+// Get returns the cached result of a URL if it was checked within maxAge.
+// A zero maxAge accepts any cached result.
+func (c *Cache) Get(url string, maxAge time.Duration, now time.Time) (Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result, ok := c.entries[url]
+	if !ok {
+		return Result{}, false
+	}
+	if maxAge > 0 && now.Sub(result.CheckedAt) > maxAge {
+		return Result{}, false
+	}
+	return result, true
+}
+
+// Put records a result.
+func (c *Cache) Put(result Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[result.URL] = result
+}
+
+// Save writes the cache back to its file, sorted by URL.
+func (c *Cache) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.mu.Lock()
+	file := cacheFile{Results: make([]Result, 0, len(c.entries))}
+	for _, result := range c.entries {
+		file.Results = append(file.Results, result)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(file.Results, func(i, j int) bool {
+		return file.Results[i].URL < file.Results[j].URL
+	})
+
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, append(content, '\n'), 0644)
+}
@@ -0,0 +1,246 @@
+package links
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Result is the outcome of checking a link.
+type Result struct {
+	URL       string    `json:"url"`
+	Status    int       `json:"status,omitempty"` // Final HTTP status, 0 on network errors
+	Error     string    `json:"error,omitempty"`  // Why the link is broken, empty if OK
+	CheckedAt time.Time `json:"checked_at"`
+	Cached    bool      `json:"-"` // Result came from the cache
+}
+
+// OK reports whether the link is reachable.
+func (r Result) OK() bool {
+	return r.Error == ""
+}
+
+// Checker checks links over HTTP.
+type Checker struct {
+	Client      *http.Client
+	Cache       *Cache
+	Concurrency int           // Parallel requests (default 8)
+	RateLimit   time.Duration // Minimum delay between requests to the same host
+	Retries     int           // Retries for network errors, 429 and 5xx responses
+	RetryDelay  time.Duration // Delay before the first retry, doubled for each attempt (default 1s)
+	MaxAge      time.Duration // Reuse cached results younger than this (default DefaultMaxAge)
+	Offline     bool          // Only use the cache, at any age; uncached links are not checked
+	UserAgent   string
+
+	mu       sync.Mutex
+	nextSlot map[string]time.Time // host -> earliest time of the next request
+}
+
+// DefaultMaxAge is how long cached results are reused by online checks.
+const DefaultMaxAge = 24 * time.Hour
+
+// errNotCached marks links skipped in offline mode.
+const errNotCached = "not in cache (offline)"
+
+// Check checks each URL once and returns the results keyed by URL.
+// In offline mode, uncached URLs are returned with Status -1 and are not
+// considered broken by Broken.
+func (c *Checker) Check(ctx context.Context, urls []string) map[string]Result {
+	concurrency := c.Concurrency
+	if concurrency <= 0 {
+		concurrency = 8
+	}
+
+	unique := make([]string, 0, len(urls))
+	seen := make(map[string]bool)
+	for _, u := range urls {
+		if !seen[u] {
+			seen[u] = true
+			unique = append(unique, u)
+		}
+	}
+
+	results := make(map[string]Result, len(unique))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+
+	for _, u := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(u string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := c.checkOne(ctx, u)
+			mu.Lock()
+			results[u] = result
+			mu.Unlock()
+		}(u)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Broken reports whether a result is a broken link (as opposed to OK or unchecked).
+func Broken(r Result) bool {
+	return !r.OK() && r.Error != errNotCached
+}
+
+// checkOne checks a URL, using the cache when possible.
+func (c *Checker) checkOne(ctx context.Context, rawURL string) Result {
+	now := time.Now()
+	if err := ValidateSyntax(rawURL); err != nil {
+		return Result{URL: rawURL, Error: err.Error(), CheckedAt: now}
+	}
+
+	if c.Cache != nil {
+		maxAge := c.MaxAge
+		if maxAge <= 0 {
+			maxAge = DefaultMaxAge
+		}
+		if c.Offline {
+			maxAge = 0 // Any age
+		}
+		if result, ok := c.Cache.Get(rawURL, maxAge, now); ok {
+			result.Cached = true
+			return result
+		}
+	}
+	if c.Offline {
+		return Result{URL: rawURL, Status: -1, Error: errNotCached, CheckedAt: now}
+	}
+
+	// Transient failures are rechecked on the next run instead of being cached
+	result := c.fetch(ctx, rawURL)
+	if c.Cache != nil && !transient(result) {
+		c.Cache.Put(result)
+	}
+	return result
+}
+
+// transient reports whether a result is a failure that may go away on its
+// own: a network error, 429 or a 5xx response.
+func transient(r Result) bool {
+	return !r.OK() && (r.Status == 0 || r.Status == http.StatusTooManyRequests || r.Status >= 500)
+}
+
+// fetch requests a URL with retries. HEAD is tried first and GET is used
+// when the server does not support HEAD.
+func (c *Checker) fetch(ctx context.Context, rawURL string) Result {
+	retryDelay := c.RetryDelay
+	if retryDelay <= 0 {
+		retryDelay = time.Second
+	}
+
+	var status int
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retryAfter time.Duration
+		status, retryAfter, err = c.request(ctx, http.MethodHead, rawURL)
+		if err == nil && headUnsupported(status) {
+			status, retryAfter, err = c.request(ctx, http.MethodGet, rawURL)
+		}
+
+		if attempt >= c.Retries || !retryable(status, err) {
+			break
+		}
+
+		delay := retryDelay << attempt
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		select {
+		case <-ctx.Done():
+			return Result{URL: rawURL, Error: ctx.Err().Error(), CheckedAt: time.Now()}
+		case <-time.After(delay):
+		}
+	}
+
+	result := Result{URL: rawURL, Status: status, CheckedAt: time.Now()}
+	switch {
+	case err != nil:
+		result.Error = err.Error()
+	case status >= 400:
+		result.Error = fmt.Sprintf("HTTP %d", status)
+	}
+	return result
+}
+
+// request performs a single request after waiting for the host's rate limit slot.
+// It returns the status and the server's Retry-After delay, if any.
+func (c *Checker) request(ctx context.Context, method, rawURL string) (int, time.Duration, error) {
+	if err := c.wait(ctx, rawURL); err != nil {
+		return 0, 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 15 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp.Body.Close()
+
+	var retryAfter time.Duration
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	return resp.StatusCode, retryAfter, nil
+}
+
+// wait blocks until a request to the URL's host is allowed by the rate limit.
+func (c *Checker) wait(ctx context.Context, rawURL string) error {
+	if c.RateLimit <= 0 {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if c.nextSlot == nil {
+		c.nextSlot = make(map[string]time.Time)
+	}
+	now := time.Now()
+	slot := c.nextSlot[u.Host]
+	if slot.Before(now) {
+		slot = now
+	}
+	c.nextSlot[u.Host] = slot.Add(c.RateLimit)
+	c.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(time.Until(slot)):
+		return nil
+	}
+}
+
+// headUnsupported reports whether a HEAD status suggests retrying with GET.
+func headUnsupported(status int) bool {
+	return status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented ||
+		status == http.StatusForbidden || status == http.StatusNotFound
+}
+
+// retryable reports whether a request outcome is worth retrying.
+func retryable(status int, err error) bool {
+	return err != nil || status == http.StatusTooManyRequests || status >= 500
+}
@@ -0,0 +1,142 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/docs"
+)
+
+// Link sources.
+const (
+	SourceAppLink            = "app_links"
+	SourceProjectDescription = "project_description"
+	SourceSection            = "section"
+)
+
+var (
+	// markdownLinkRe matches inline markdown links and images, capturing the target.
+	markdownLinkRe = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+	// autolinkRe matches <https://...> autolinks.
+	autolinkRe = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+
+	// hrefRe matches href/src attributes in HTML.
+	hrefRe = regexp.MustCompile(`(?:href|src)="(https?://[^"]+)"`)
+)
+
+// Link is an external link found in a documentation file.
+type Link struct {
+	URL    string
+	File   string // Path of the document
+	Line   int    // 1-based line number, 0 if unknown
+	Source string // SourceAppLink, SourceProjectDescription or SourceSection
+}
+
+// Location returns "file:line" for the link.
+func (l Link) Location() string {
+	if l.Line == 0 {
+		return l.File
+	}
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
+// Extract returns the links of a document: app_links and the project link
+// from its saltbox_automation frontmatter, and the http(s) links inside the
+// given managed sections. Links inside sections are returned in order of
+// appearance; relative links are left to the cross-reference checker.
+func Extract(doc *docs.Document, sections []string) []Link {
+	var result []Link
+
+	if doc.Frontmatter != nil && doc.Frontmatter.SaltboxAutomation != nil {
+		sa := doc.Frontmatter.SaltboxAutomation
+		if sa.ProjectDescription != nil && sa.ProjectDescription.Link != "" {
+			result = append(result, Link{
+				URL:    sa.ProjectDescription.Link,
+				File:   doc.Path,
+				Line:   lineOf(doc.Content, sa.ProjectDescription.Link),
+				Source: SourceProjectDescription,
+			})
+		}
+		for _, link := range sa.AppLinks {
+			result = append(result, Link{
+				URL:    link.URL,
+				File:   doc.Path,
+				Line:   lineOf(doc.Content, link.URL),
+				Source: SourceAppLink,
+			})
+		}
+	}
+
+	for _, name := range sections {
+		if name == "" {
+			continue
+		}
+		section := docs.FindManagedSection(doc.Content, name)
+		if section == nil {
+			continue
+		}
+		for i, line := range strings.Split(section.Content, "\n") {
+			for _, target := range lineLinks(line) {
+				if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+					continue
+				}
+				result = append(result, Link{
+					URL:    target,
+					File:   doc.Path,
+					Line:   section.StartLine + i,
+					Source: SourceSection,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// lineLinks returns the link targets on a line of markdown.
+func lineLinks(line string) []string {
+	var targets []string
+	for _, re := range []*regexp.Regexp{markdownLinkRe, autolinkRe, hrefRe} {
+		for _, match := range re.FindAllStringSubmatch(line, -1) {
+			targets = append(targets, match[1])
+		}
+	}
+	return targets
+}
+
+// lineOf returns the 1-based line of the first occurrence of s, or 0 if it does not occur.
+func lineOf(content, s string) int {
+	if s == "" {
+		return 0
+	}
+	for i, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, s) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ValidateSyntax checks that a link is an absolute http(s) URL with a host.
+func ValidateSyntax(raw string) error {
+	if raw == "" {
+		return fmt.Errorf("empty URL")
+	}
+	if strings.ContainsAny(raw, " \t\n") {
+		return fmt.Errorf("URL contains whitespace")
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q (expected http or https)", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
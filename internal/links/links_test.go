@@ -0,0 +1,199 @@
+package links
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/saltyorg/docs-automation/internal/docs"
+)
+
+func TestExtract(t *testing.T) {
+	content := `---
+saltbox_automation:
+  project_description:
+    name: Plex
+    link: https://www.plex.tv
+  app_links:
+    - name: Manual
+      url: https://support.plex.tv
+---
+# Plex
+
+[ignored](https://outside.example.com)
+
+<!-- BEGIN OVERVIEW -->
+| [Docs](https://docs.example.com "title") | <https://auto.example.com> |
+| [Relative](../other.md) | <a href="https://html.example.com">x</a> |
+<!-- END OVERVIEW -->
+`
+	fm, body, err := docs.ParseFrontmatter(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := &docs.Document{Path: "plex.md", Content: content, Frontmatter: fm, Body: body}
+
+	expected := []Link{
+		{URL: "https://www.plex.tv", File: "plex.md", Line: 5, Source: SourceProjectDescription},
+		{URL: "https://support.plex.tv", File: "plex.md", Line: 8, Source: SourceAppLink},
+		{URL: "https://docs.example.com", File: "plex.md", Line: 15, Source: SourceSection},
+		{URL: "https://auto.example.com", File: "plex.md", Line: 15, Source: SourceSection},
+		{URL: "https://html.example.com", File: "plex.md", Line: 16, Source: SourceSection},
+	}
+	if got := Extract(doc, []string{"OVERVIEW", "MISSING"}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Extract =\n%+v\nwant\n%+v", got, expected)
+	}
+}
+
+func TestValidateSyntax(t *testing.T) {
+	tests := map[string]bool{
+		"https://example.com/path": true,
+		"http://example.com":       true,
+		"":                         false,
+		"example.com":              false,
+		"ftp://example.com":        false,
+		"https://":                 false,
+		"https://exa mple.com":     false,
+	}
+	for url, valid := range tests {
+		if err := ValidateSyntax(url); (err == nil) != valid {
+			t.Errorf("ValidateSyntax(%q) = %v, want valid=%v", url, err, valid)
+		}
+	}
+}
+
+func TestChecker(t *testing.T) {
+	var flakyCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/flaky":
+			if flakyCalls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	cachePath := filepath.Join(t.TempDir(), "links.json")
+	cache, err := LoadCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker := &Checker{
+		Client:     server.Client(),
+		Cache:      cache,
+		RateLimit:  time.Millisecond,
+		Retries:    2,
+		RetryDelay: time.Millisecond,
+	}
+	urls := []string{server.URL + "/ok", server.URL + "/missing", server.URL + "/no-head", server.URL + "/flaky", server.URL + "/ok", "not a url"}
+	results := checker.Check(context.Background(), urls)
+
+	expected := map[string]bool{
+		server.URL + "/ok":      true,
+		server.URL + "/missing": false,
+		server.URL + "/no-head": true,
+		server.URL + "/flaky":   true,
+		"not a url":             false,
+	}
+	if len(results) != len(expected) {
+		t.Errorf("Check returned %d results, want %d", len(results), len(expected))
+	}
+	for url, ok := range expected {
+		if results[url].OK() != ok {
+			t.Errorf("%s: OK = %v (%+v), want %v", url, results[url].OK(), results[url], ok)
+		}
+	}
+	if results[server.URL+"/missing"].Status != http.StatusNotFound {
+		t.Errorf("missing status = %d, want 404", results[server.URL+"/missing"].Status)
+	}
+
+	if err := cache.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	// Offline runs only use the recorded cache
+	cache, err = LoadCache(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline := &Checker{Cache: cache, Offline: true}
+	results = offline.Check(context.Background(), []string{server.URL + "/ok", server.URL + "/missing", server.URL + "/uncached"})
+
+	if !results[server.URL+"/ok"].OK() || !results[server.URL+"/ok"].Cached {
+		t.Errorf("cached OK result not reused: %+v", results[server.URL+"/ok"])
+	}
+	if !Broken(results[server.URL+"/missing"]) {
+		t.Errorf("cached broken result not reused: %+v", results[server.URL+"/missing"])
+	}
+	if uncached := results[server.URL+"/uncached"]; uncached.OK() || Broken(uncached) {
+		t.Errorf("uncached link should be unchecked, got %+v", uncached)
+	}
+}
+
+func TestChecker_CacheExpiry(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/revived", "/fresh":
+			w.WriteHeader(http.StatusOK)
+		case "/down":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cache, err := LoadCache(filepath.Join(t.TempDir(), "links.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	cache.Put(Result{URL: server.URL + "/revived", Status: http.StatusNotFound, Error: "HTTP 404", CheckedAt: now.Add(-48 * time.Hour)})
+	cache.Put(Result{URL: server.URL + "/fresh", CheckedAt: now.Add(-time.Hour)})
+
+	checker := &Checker{Client: server.Client(), Cache: cache, RetryDelay: time.Millisecond}
+	results := checker.Check(context.Background(), []string{server.URL + "/revived", server.URL + "/fresh", server.URL + "/down"})
+
+	if r := results[server.URL+"/revived"]; !r.OK() || r.Cached {
+		t.Errorf("stale result should be rechecked with the default max age, got %+v", r)
+	}
+	if r := results[server.URL+"/fresh"]; !r.Cached {
+		t.Errorf("fresh result should come from the cache, got %+v", r)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("made %d requests, want 2", calls.Load())
+	}
+	if !Broken(results[server.URL+"/down"]) {
+		t.Errorf("503 should be reported as broken, got %+v", results[server.URL+"/down"])
+	}
+	if _, ok := cache.Get(server.URL+"/down", 0, now); ok {
+		t.Error("transient failures should not be cached")
+	}
+
+	// Offline runs reuse results of any age
+	cache.Put(Result{URL: server.URL + "/old", CheckedAt: now.Add(-365 * 24 * time.Hour)})
+	offline := &Checker{Cache: cache, Offline: true}
+	if r := offline.Check(context.Background(), []string{server.URL + "/old"})[server.URL+"/old"]; !r.Cached {
+		t.Errorf("offline run should reuse an old result, got %+v", r)
+	}
+}
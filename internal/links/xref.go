@@ -0,0 +1,432 @@
+package links
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/funcs"
+)
+
+var (
+	// refDefinitionRe matches reference-style link definitions: [id]: target
+	refDefinitionRe = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+
+	// headingRe matches ATX headings, capturing the text and an optional { #id } attribute.
+	headingRe = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)\s*(?:\{\s*#([\w-]+)[^}]*\})?\s*#*\s*$`)
+
+	// htmlIDRe matches id and name attributes of HTML elements.
+	htmlIDRe = regexp.MustCompile(`<[a-zA-Z][^>]*\s(?:id|name)="([^"]+)"`)
+
+	// attrIDRe matches attribute lists with an id on other elements: { #id }
+	attrIDRe = regexp.MustCompile(`\{\s*#([\w-]+)[^}]*\}`)
+
+	// inlineCodeRe matches inline code spans.
+	inlineCodeRe = regexp.MustCompile("`[^`]*`")
+
+	// headingLinkRe matches links inside heading text, capturing the link text.
+	headingLinkRe = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+
+	// schemeRe matches links with a URL scheme (external links, mailto, ...).
+	schemeRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// BrokenRef is an internal link that does not resolve.
+type BrokenRef struct {
+	File       string // Document containing the link
+	Line       int    // 1-based line of the link
+	Target     string // Link target as written
+	Reason     string // Why the link does not resolve
+	Suggestion string // Replacement target when the page was moved, "" if unknown
+}
+
+// Location returns "file:line" for the link.
+func (r BrokenRef) Location() string {
+	return fmt.Sprintf("%s:%d", r.File, r.Line)
+}
+
+// RefChecker validates relative links and #anchors across a docs tree.
+type RefChecker struct {
+	root    string
+	files   map[string]bool            // absolute paths of all files in the tree
+	anchors map[string]map[string]bool // markdown file -> heading and element ids
+	moved   map[string]string          // old page path -> new page path
+}
+
+// NewRefChecker indexes the files and markdown anchors under root.
+func NewRefChecker(root string) (*RefChecker, error) {
+	c := &RefChecker{
+		root:    root,
+		files:   make(map[string]bool),
+		anchors: make(map[string]map[string]bool),
+		moved:   make(map[string]string),
+	}
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		c.files[path] = true
+		if strings.HasSuffix(path, ".md") {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			c.anchors[path] = Anchors(string(content))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddMove records that the page at oldPath now lives at newPath, so links
+// to oldPath get a rewrite suggestion. Paths are absolute.
+func (c *RefChecker) AddMove(oldPath, newPath string) {
+	c.moved[filepath.Clean(oldPath)] = filepath.Clean(newPath)
+}
+
+// Check validates every internal link of all markdown files in the tree.
+// Results are sorted by file and line.
+func (c *RefChecker) Check() []BrokenRef {
+	paths := make([]string, 0, len(c.anchors))
+	for path := range c.anchors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var broken []BrokenRef
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		broken = append(broken, c.CheckFile(path, string(content))...)
+	}
+	return broken
+}
+
+// CheckFile validates the internal links of one markdown file.
+func (c *RefChecker) CheckFile(path, content string) []BrokenRef {
+	var broken []BrokenRef
+	for _, ref := range InternalLinks(content) {
+		reason, suggestion := c.resolve(path, ref.Target)
+		if reason == "" {
+			continue
+		}
+		broken = append(broken, BrokenRef{
+			File:       path,
+			Line:       ref.Line,
+			Target:     ref.Target,
+			Reason:     reason,
+			Suggestion: suggestion,
+		})
+	}
+	return broken
+}
+
+// resolve checks a link target from a file. It returns why the link is
+// broken ("" if it resolves) and a replacement target when one is known.
+func (c *RefChecker) resolve(from, target string) (reason, suggestion string) {
+	dest, anchor, err := c.destination(from, target)
+	if err != nil {
+		return fmt.Sprintf("invalid path: %v", err), ""
+	}
+
+	if !c.files[dest] {
+		// Directory links resolve to their index page
+		if index := filepath.Join(dest, "index.md"); c.files[index] {
+			dest = index
+		} else {
+			if newPath := c.findMoved(dest); newPath != "" {
+				suggestion = relativeTarget(from, newPath, anchor)
+				if anchor != "" && !c.anchors[newPath][anchor] {
+					suggestion = ""
+				}
+			}
+			return "file not found", suggestion
+		}
+	}
+
+	if anchor != "" && strings.HasSuffix(dest, ".md") && !c.anchors[dest][anchor] {
+		return fmt.Sprintf("anchor #%s not found", anchor), ""
+	}
+	return "", ""
+}
+
+// destination returns the file a link target points to (before directory
+// index resolution) and its anchor.
+func (c *RefChecker) destination(from, target string) (dest, anchor string, err error) {
+	rawPath, anchor, _ := strings.Cut(target, "#")
+	decoded, err := url.PathUnescape(rawPath)
+	if err != nil {
+		return "", "", err
+	}
+
+	dest = from
+	if decoded != "" {
+		if strings.HasPrefix(decoded, "/") {
+			dest = filepath.Join(c.root, decoded)
+		} else {
+			dest = filepath.Join(filepath.Dir(from), decoded)
+		}
+	}
+	return dest, anchor, nil
+}
+
+// Retarget returns the links of all markdown files in the tree that point
+// to oldPath, with a Suggestion pointing to newPath instead (keeping the
+// anchor). Links from oldPath itself are resolved as if the page already
+// lived at newPath. Results are sorted by file and line.
+func (c *RefChecker) Retarget(oldPath, newPath string) []BrokenRef {
+	oldPath, newPath = filepath.Clean(oldPath), filepath.Clean(newPath)
+
+	paths := make([]string, 0, len(c.anchors))
+	for path := range c.anchors {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var refs []BrokenRef
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		from := path
+		if path == oldPath {
+			from = newPath
+		}
+		for _, link := range InternalLinks(string(content)) {
+			dest, anchor, err := c.destination(path, link.Target)
+			if err != nil || dest != oldPath || strings.HasPrefix(link.Target, "#") {
+				continue
+			}
+			suggestion := relativeTarget(from, newPath, anchor)
+			if strings.HasPrefix(link.Target, "/") {
+				rel, err := filepath.Rel(c.root, newPath)
+				if err != nil {
+					continue
+				}
+				suggestion = "/" + filepath.ToSlash(rel)
+				if anchor != "" {
+					suggestion += "#" + anchor
+				}
+			}
+			refs = append(refs, BrokenRef{
+				File:       path,
+				Line:       link.Line,
+				Target:     link.Target,
+				Reason:     "page renamed",
+				Suggestion: suggestion,
+			})
+		}
+	}
+	return refs
+}
+
+// Rebase returns the relative links of the page at oldPath whose target
+// changes once the page lives at newPath, with the Suggestion set to the
+// rewritten target. Absolute links, anchor-only links and links to the page
+// itself (see Retarget) are unaffected.
+func (c *RefChecker) Rebase(oldPath, newPath string) []BrokenRef {
+	oldPath, newPath = filepath.Clean(oldPath), filepath.Clean(newPath)
+	content, err := os.ReadFile(oldPath)
+	if err != nil {
+		return nil
+	}
+
+	var refs []BrokenRef
+	for _, link := range InternalLinks(string(content)) {
+		if strings.HasPrefix(link.Target, "/") || strings.HasPrefix(link.Target, "#") {
+			continue
+		}
+		dest, anchor, err := c.destination(oldPath, link.Target)
+		if err != nil || dest == oldPath {
+			continue
+		}
+		suggestion := relativeTarget(newPath, dest, "")
+		if rawPath, _, _ := strings.Cut(link.Target, "#"); strings.HasSuffix(rawPath, "/") {
+			suggestion += "/" // directory link
+		}
+		if anchor != "" {
+			suggestion += "#" + anchor
+		}
+		if suggestion == link.Target {
+			continue
+		}
+		refs = append(refs, BrokenRef{
+			File:       oldPath,
+			Line:       link.Line,
+			Target:     link.Target,
+			Reason:     "page moved",
+			Suggestion: suggestion,
+		})
+	}
+	return refs
+}
+
+// findMoved returns the new location of a missing page: a recorded move,
+// otherwise the only markdown file in the tree with the same name.
+func (c *RefChecker) findMoved(dest string) string {
+	if newPath, ok := c.moved[dest]; ok {
+		return newPath
+	}
+	if !strings.HasSuffix(dest, ".md") {
+		return ""
+	}
+
+	var match string
+	for path := range c.anchors {
+		if filepath.Base(path) == filepath.Base(dest) {
+			if match != "" {
+				return ""
+			}
+			match = path
+		}
+	}
+	return match
+}
+
+// relativeTarget returns the link from one file to another, with an optional anchor.
+func relativeTarget(from, to, anchor string) string {
+	rel, err := filepath.Rel(filepath.Dir(from), to)
+	if err != nil {
+		return ""
+	}
+	target := filepath.ToSlash(rel)
+	if anchor != "" {
+		target += "#" + anchor
+	}
+	return target
+}
+
+// InternalLink is a relative link target found in markdown.
+type InternalLink struct {
+	Target string
+	Line   int
+}
+
+// InternalLinks returns the relative link targets of markdown content:
+// inline links, images and reference definitions outside code. Links with
+// a scheme (http:, mailto:, ...) are skipped.
+func InternalLinks(content string) []InternalLink {
+	var result []InternalLink
+	forEachProse(content, func(line string, lineNum int) {
+		line = inlineCodeRe.ReplaceAllString(line, "")
+		var targets []string
+		for _, match := range markdownLinkRe.FindAllStringSubmatch(line, -1) {
+			targets = append(targets, match[1])
+		}
+		if match := refDefinitionRe.FindStringSubmatch(line); match != nil {
+			targets = append(targets, match[1])
+		}
+		for _, target := range targets {
+			if target == "" || schemeRe.MatchString(target) || strings.HasPrefix(target, "//") {
+				continue
+			}
+			result = append(result, InternalLink{Target: target, Line: lineNum})
+		}
+	})
+	return result
+}
+
+// Anchors returns the anchor ids of a markdown page: MkDocs heading slugs
+// (with _1, _2 suffixes for duplicates), { #id } attributes and HTML ids.
+func Anchors(content string) map[string]bool {
+	anchors := make(map[string]bool)
+	forEachProse(content, func(line string, _ int) {
+		if match := headingRe.FindStringSubmatch(line); match != nil {
+			id := match[2]
+			if id == "" {
+				text := headingLinkRe.ReplaceAllString(match[1], "$1")
+				id = uniqueSlug(funcs.Slugify(text), anchors)
+			}
+			anchors[id] = true
+		} else {
+			for _, m := range attrIDRe.FindAllStringSubmatch(line, -1) {
+				anchors[m[1]] = true
+			}
+		}
+		for _, m := range htmlIDRe.FindAllStringSubmatch(line, -1) {
+			anchors[m[1]] = true
+		}
+	})
+	return anchors
+}
+
+// uniqueSlug makes a heading slug unique the way Python-Markdown does.
+func uniqueSlug(slug string, existing map[string]bool) string {
+	if !existing[slug] {
+		return slug
+	}
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s_%d", slug, i)
+		if !existing[candidate] {
+			return candidate
+		}
+	}
+}
+
+// forEachProse calls fn for every line outside frontmatter and fenced code blocks.
+func forEachProse(content string, fn func(line string, lineNum int)) {
+	lines := strings.Split(content, "\n")
+	start := 0
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	fence := ""
+	for i := start; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		fn(lines[i], i+1)
+	}
+}
+
+// ApplySuggestions rewrites the targets of broken links that have a
+// suggestion. refs must belong to content; it returns the updated content
+// and the number of links rewritten.
+func ApplySuggestions(content string, refs []BrokenRef) (string, int) {
+	lines := strings.Split(content, "\n")
+	count := 0
+	for _, ref := range refs {
+		if ref.Suggestion == "" || ref.Line < 1 || ref.Line > len(lines) {
+			continue
+		}
+		line := lines[ref.Line-1]
+		for _, wrap := range [][2]string{{"(", ")"}, {"(", " "}, {"(<", ">"}, {"]: ", ""}} {
+			old := wrap[0] + ref.Target + wrap[1]
+			if strings.Contains(line, old) {
+				line = strings.Replace(line, old, wrap[0]+ref.Suggestion+wrap[1], 1)
+				count++
+				break
+			}
+		}
+		lines[ref.Line-1] = line
+	}
+	return strings.Join(lines, "\n"), count
+}
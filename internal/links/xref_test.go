@@ -0,0 +1,156 @@
+package links
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAnchors(t *testing.T) {
+	content := `---
+title: "# Not a heading"
+---
+# Plex
+
+## Docker+ Options
+
+## Docker+ Options
+
+### Custom Id { #custom-id }
+
+## [Linked](https://example.com) heading
+
+` + "```" + `
+# comment in code
+` + "```" + `
+
+<span id="html-id"></span>
+`
+	expected := map[string]bool{
+		"plex":             true,
+		"docker-options":   true,
+		"docker-options_1": true,
+		"custom-id":        true,
+		"linked-heading":   true,
+		"html-id":          true,
+	}
+	if got := Anchors(content); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Anchors = %v, want %v", got, expected)
+	}
+}
+
+func TestRefChecker(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"index.md":               "# Home\n",
+		"reference/docker.md":    "# Docker+\n\n## Options\n",
+		"apps/media/plex.md":     "# Plex\n\n## Usage\n",
+		"apps/sonarr-v4.md":      "# Sonarr\n",
+		"images/logo.png":        "",
+		"apps/index.md":          "# Apps\n",
+		"sandbox/apps/sonarr.md": "# Sonarr\n\n[Main](../../apps/sonarr.md)\n",
+		"guides/overrides.md": `# Overrides
+
+[Docker+](../reference/docker.md#options)
+[Bad anchor](../reference/docker.md#missing)
+[Local](#overrides)
+[Plex](../apps/plex.md#usage)
+[Apps](../apps/)
+![Logo](../images/logo.png)
+[Ext](https://example.com/missing.md)
+` + "`[code](missing.md)`" + `
+
+[ref]: ../missing.md
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checker, err := NewRefChecker(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker.AddMove(filepath.Join(root, "apps/sonarr.md"), filepath.Join(root, "apps/sonarr-v4.md"))
+
+	overrides := filepath.Join(root, "guides/overrides.md")
+	sandbox := filepath.Join(root, "sandbox/apps/sonarr.md")
+	expected := []BrokenRef{
+		{File: overrides, Line: 4, Target: "../reference/docker.md#missing", Reason: "anchor #missing not found"},
+		{File: overrides, Line: 6, Target: "../apps/plex.md#usage", Reason: "file not found", Suggestion: "../apps/media/plex.md#usage"},
+		{File: overrides, Line: 12, Target: "../missing.md", Reason: "file not found"},
+		{File: sandbox, Line: 3, Target: "../../apps/sonarr.md", Reason: "file not found", Suggestion: "../../apps/sonarr-v4.md"},
+	}
+	got := checker.Check()
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Check =\n%+v\nwant\n%+v", got, expected)
+	}
+
+	content, _ := os.ReadFile(overrides)
+	updated, count := ApplySuggestions(string(content), got[:3])
+	if count != 1 {
+		t.Errorf("ApplySuggestions rewrote %d links, want 1", count)
+	}
+	if refs := checker.CheckFile(overrides, updated); len(refs) != 2 {
+		t.Errorf("after rewrite %d broken links remain, want 2: %+v", len(refs), refs)
+	}
+}
+
+func TestRetarget(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"apps/organizr.md":     "# Organizr\n\n[Self](organizr.md#usage)\n[Top](#organizr)\n",
+		"apps/plex.md":         "# Plex\n\n[Organizr](organizr.md) [Abs](/apps/organizr.md)\n",
+		"guides/dashboards.md": "# Dashboards\n\n[Home](../apps/organizr.md#usage)\n[Plex](../apps/plex.md)\n",
+		"guides/unrelated.md":  "# Unrelated\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checker, err := NewRefChecker(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPath := filepath.Join(root, "apps/organizr.md")
+	plex := filepath.Join(root, "apps/plex.md")
+	guide := filepath.Join(root, "guides/dashboards.md")
+	expected := []BrokenRef{
+		{File: oldPath, Line: 3, Target: "organizr.md#usage", Reason: "page renamed", Suggestion: "organizr2.md#usage"},
+		{File: plex, Line: 3, Target: "organizr.md", Reason: "page renamed", Suggestion: "organizr2.md"},
+		{File: plex, Line: 3, Target: "/apps/organizr.md", Reason: "page renamed", Suggestion: "/apps/organizr2.md"},
+		{File: guide, Line: 3, Target: "../apps/organizr.md#usage", Reason: "page renamed", Suggestion: "../apps/organizr2.md#usage"},
+	}
+	got := checker.Retarget(oldPath, filepath.Join(root, "apps/organizr2.md"))
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Retarget =\n%+v\nwant\n%+v", got, expected)
+	}
+
+	archived := filepath.Join(root, "archive/apps/organizr.md")
+	guideFrom := filepath.Join(root, "guides/dashboards.md")
+	rebased := checker.Rebase(guideFrom, filepath.Join(root, "archive/guides/dashboards.md"))
+	expectedRebased := []BrokenRef{
+		{File: guideFrom, Line: 3, Target: "../apps/organizr.md#usage", Reason: "page moved", Suggestion: "../../apps/organizr.md#usage"},
+		{File: guideFrom, Line: 4, Target: "../apps/plex.md", Reason: "page moved", Suggestion: "../../apps/plex.md"},
+	}
+	if !reflect.DeepEqual(rebased, expectedRebased) {
+		t.Errorf("Rebase =\n%+v\nwant\n%+v", rebased, expectedRebased)
+	}
+	if refs := checker.Rebase(oldPath, archived); len(refs) != 0 {
+		t.Errorf("Rebase of self links = %+v, want none", refs)
+	}
+}
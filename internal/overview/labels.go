@@ -0,0 +1,270 @@
+package overview
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/docs"
+	"gopkg.in/yaml.v3"
+)
+
+// OCI image annotation keys read from image labels.
+const (
+	LabelTitle         = "org.opencontainers.image.title"
+	LabelDescription   = "org.opencontainers.image.description"
+	LabelURL           = "org.opencontainers.image.url"
+	LabelDocumentation = "org.opencontainers.image.documentation"
+	LabelSource        = "org.opencontainers.image.source"
+)
+
+// ImageLabels holds the labels of an image.
+type ImageLabels map[string]string
+
+// LabelCache maps normalized image references (see NormalizeImageRef) to their labels.
+type LabelCache map[string]ImageLabels
+
+// LoadLabelCache reads image labels from a JSON file or a directory of JSON files.
+//
+// Each file may contain:
+//   - a map of image references to label sets
+//   - "docker inspect" output (an array of objects with RepoTags and Config.Labels)
+//   - a single image config ({"config": {"Labels": ...}}) or label set, keyed by
+//     its file name with "_" standing in for "/" (e.g. "plexinc_pms-docker.json")
+func LoadLabelCache(path string) (LabelCache, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+	}
+
+	cache := make(LabelCache)
+	for _, file := range files {
+		if err := cache.loadFile(file); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	return cache, nil
+}
+
+// loadFile merges the labels of one JSON file into the cache.
+func (c LabelCache) loadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	// docker inspect output
+	var inspect []struct {
+		RepoTags []string
+		Config   struct{ Labels ImageLabels }
+	}
+	if err := json.Unmarshal(content, &inspect); err == nil {
+		for _, image := range inspect {
+			for _, tag := range image.RepoTags {
+				c[NormalizeImageRef(tag)] = image.Config.Labels
+			}
+		}
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(content, &object); err != nil {
+		return fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	// Single image config, keyed by file name
+	fileRef := strings.ReplaceAll(strings.TrimSuffix(filepath.Base(path), ".json"), "_", "/")
+	for _, key := range []string{"config", "Config"} {
+		if raw, ok := object[key]; ok {
+			var config struct{ Labels ImageLabels }
+			if err := json.Unmarshal(raw, &config); err != nil {
+				return fmt.Errorf("parsing %s: %w", key, err)
+			}
+			c[NormalizeImageRef(fileRef)] = config.Labels
+			return nil
+		}
+	}
+
+	// Map of image references to label sets
+	var images map[string]ImageLabels
+	if err := json.Unmarshal(content, &images); err == nil {
+		for ref, labels := range images {
+			c[NormalizeImageRef(ref)] = labels
+		}
+		return nil
+	}
+
+	// Single label set, keyed by file name
+	var labels ImageLabels
+	if err := json.Unmarshal(content, &labels); err != nil {
+		return fmt.Errorf("unrecognized label file format")
+	}
+	c[NormalizeImageRef(fileRef)] = labels
+	return nil
+}
+
+// Lookup returns the labels of an image reference, ignoring its tag, digest and default registry.
+func (c LabelCache) Lookup(ref string) (ImageLabels, bool) {
+	labels, ok := c[NormalizeImageRef(ref)]
+	return labels, ok
+}
+
+// NormalizeImageRef reduces an image reference to its repository:
+// the tag and digest are dropped, as are the docker.io registry and library/ namespace.
+func NormalizeImageRef(ref string) string {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	for _, prefix := range []string{"docker.io/", "index.docker.io/", "registry-1.docker.io/"} {
+		ref = strings.TrimPrefix(ref, prefix)
+	}
+	return strings.TrimPrefix(ref, "library/")
+}
+
+// Proposal is the overview metadata derived from image labels.
+type Proposal struct {
+	Description docs.ProjectDescription
+	Links       []docs.AppLink
+}
+
+// ProposeFromLabels maps OCI labels to a project description and app links.
+// The title becomes the name, the description the summary and the url the
+// project link; url, documentation and source also become app links.
+func ProposeFromLabels(labels ImageLabels) Proposal {
+	var p Proposal
+	p.Description.Name = strings.TrimSpace(labels[LabelTitle])
+	p.Description.Summary = strings.TrimSpace(labels[LabelDescription])
+	p.Description.Link = strings.TrimSpace(labels[LabelURL])
+
+	if url := p.Description.Link; url != "" {
+		p.Links = append(p.Links, docs.AppLink{Name: "Home", URL: url, Type: "home"})
+	}
+	if url := strings.TrimSpace(labels[LabelDocumentation]); url != "" {
+		p.Links = append(p.Links, docs.AppLink{Name: "Manual", URL: url, Type: "manual"})
+	}
+	if url := strings.TrimSpace(labels[LabelSource]); url != "" {
+		if strings.Contains(url, "github.com/") {
+			p.Links = append(p.Links, docs.AppLink{Name: "GitHub", URL: url, Type: "github"})
+		} else {
+			p.Links = append(p.Links, docs.AppLink{Name: "Source", URL: url, Type: "source"})
+		}
+	}
+	return p
+}
+
+// Empty reports whether the proposal holds no metadata.
+func (p Proposal) Empty() bool {
+	d := p.Description
+	return d.Name == "" && d.Summary == "" && d.Link == "" && len(p.Links) == 0
+}
+
+// Merge returns the parts of the proposal missing from existing frontmatter.
+// Description fields already set are kept; a link is only added when no
+// existing link has the same type or URL.
+func (p Proposal) Merge(existing *docs.SaltboxAutomationConfig) Proposal {
+	var current docs.ProjectDescription
+	var links []docs.AppLink
+	if existing != nil {
+		if existing.ProjectDescription != nil {
+			current = *existing.ProjectDescription
+		}
+		links = existing.AppLinks
+	}
+
+	var missing Proposal
+	if current.Name == "" {
+		missing.Description.Name = p.Description.Name
+	}
+	if current.Summary == "" {
+		missing.Description.Summary = p.Description.Summary
+	}
+	if current.Link == "" {
+		missing.Description.Link = p.Description.Link
+	}
+
+	for _, link := range p.Links {
+		duplicate := false
+		for _, have := range links {
+			if (have.Type != "" && have.Type == link.Type) || strings.TrimSuffix(have.URL, "/") == strings.TrimSuffix(link.URL, "/") {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			missing.Links = append(missing.Links, link)
+		}
+	}
+	return missing
+}
+
+// Apply writes the proposal into the saltbox_automation frontmatter of doc,
+// filling project_description fields and appending app_links. Other
+// frontmatter is left untouched. Use Merge first to avoid overwriting
+// hand-set fields.
+func (p Proposal) Apply(doc *docs.Document) (bool, error) {
+	if p.Empty() {
+		return false, nil
+	}
+
+	return doc.EditSaltboxAutomation(func(node *yaml.Node) (bool, error) {
+		changed := false
+
+		// Keys left empty ("app_links:") decode as null; start them afresh
+		for key, kind := range map[string]yaml.Kind{"project_description": yaml.MappingNode, "app_links": yaml.SequenceNode} {
+			if current := docs.MappingGet(node, key); current != nil && current.Kind == yaml.ScalarNode && current.Tag == "!!null" {
+				docs.MappingSet(node, key, &yaml.Node{Kind: kind})
+			}
+		}
+
+		fields := []struct{ key, value string }{
+			{"name", p.Description.Name},
+			{"summary", p.Description.Summary},
+			{"link", p.Description.Link},
+		}
+		for _, field := range fields {
+			if field.value == "" {
+				continue
+			}
+			set, err := docs.SetPath(node, []string{"project_description", field.key}, docs.ScalarNode(field.value))
+			if err != nil {
+				return false, err
+			}
+			changed = changed || set
+		}
+
+		// Links are appended at the index one past the end of the list
+		count := 0
+		if links := docs.MappingGet(node, "app_links"); links != nil && links.Kind == yaml.SequenceNode {
+			count = len(links.Content)
+		}
+		for i, link := range p.Links {
+			var item yaml.Node
+			if err := item.Encode(link); err != nil {
+				return false, err
+			}
+			set, err := docs.SetPath(node, []string{"app_links", strconv.Itoa(count + i)}, &item)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || set
+		}
+		return changed, nil
+	})
+}
@@ -0,0 +1,167 @@
+package overview
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/saltyorg/docs-automation/internal/docs"
+)
+
+func TestNormalizeImageRef(t *testing.T) {
+	tests := map[string]string{
+		"plexinc/pms-docker:latest":             "plexinc/pms-docker",
+		"docker.io/library/redis:7":             "redis",
+		"ghcr.io/hotio/sonarr@sha256:abc":       "ghcr.io/hotio/sonarr",
+		"registry.example.com:5000/app/web:1.0": "registry.example.com:5000/app/web",
+		"Traefik":                               "traefik",
+	}
+	for ref, expected := range tests {
+		if got := NormalizeImageRef(ref); got != expected {
+			t.Errorf("NormalizeImageRef(%q) = %q, want %q", ref, got, expected)
+		}
+	}
+}
+
+func TestLoadLabelCache(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"inspect.json":            `[{"RepoTags": ["hotio/sonarr:release"], "Config": {"Labels": {"org.opencontainers.image.title": "Sonarr"}}}]`,
+		"plexinc_pms-docker.json": `{"config": {"Labels": {"org.opencontainers.image.title": "Plex"}}}`,
+		"images.json":             `{"docker.io/library/redis:7": {"org.opencontainers.image.title": "Redis"}}`,
+		"traefik.json":            `{"org.opencontainers.image.title": "Traefik"}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cache, err := LoadLabelCache(dir)
+	if err != nil {
+		t.Fatalf("LoadLabelCache returned error: %v", err)
+	}
+
+	expected := map[string]string{
+		"hotio/sonarr:latest":       "Sonarr",
+		"plexinc/pms-docker:1.40":   "Plex",
+		"redis":                     "Redis",
+		"library/traefik:v3":        "Traefik",
+		"docker.io/traefik@sha256:": "Traefik",
+	}
+	for ref, title := range expected {
+		labels, ok := cache.Lookup(ref)
+		if !ok || labels[LabelTitle] != title {
+			t.Errorf("Lookup(%q) = %v, %v; want title %q", ref, labels, ok, title)
+		}
+	}
+}
+
+func TestProposalMerge(t *testing.T) {
+	proposal := ProposeFromLabels(ImageLabels{
+		LabelTitle:         "Plex Media Server",
+		LabelDescription:   "Stream your media",
+		LabelURL:           "https://www.plex.tv",
+		LabelDocumentation: "https://support.plex.tv",
+		LabelSource:        "https://github.com/plexinc/pms-docker",
+	})
+
+	existing := &docs.SaltboxAutomationConfig{
+		ProjectDescription: &docs.ProjectDescription{Name: "Plex"},
+		AppLinks: []docs.AppLink{
+			{Name: "Docs", URL: "https://support.plex.tv/", Type: "manual"},
+		},
+	}
+
+	missing := proposal.Merge(existing)
+	expected := Proposal{
+		Description: docs.ProjectDescription{Summary: "Stream your media", Link: "https://www.plex.tv"},
+		Links: []docs.AppLink{
+			{Name: "Home", URL: "https://www.plex.tv", Type: "home"},
+			{Name: "GitHub", URL: "https://github.com/plexinc/pms-docker", Type: "github"},
+		},
+	}
+	if !reflect.DeepEqual(missing, expected) {
+		t.Errorf("Merge = %+v, want %+v", missing, expected)
+	}
+
+	if !proposal.Merge(&docs.SaltboxAutomationConfig{
+		ProjectDescription: &docs.ProjectDescription{Name: "a", Summary: "b", Link: "c"},
+		AppLinks:           proposal.Links,
+	}).Empty() {
+		t.Error("Merge with complete frontmatter should be empty")
+	}
+}
+
+func TestProposalApply(t *testing.T) {
+	content := `---
+hide:
+  - tags # keep
+saltbox_automation:
+  sections:
+    inventory: false # comment kept
+  project_description:
+    name: Plex
+# trailing comment
+tags:
+  - media
+---
+# Plex
+`
+	doc := &docs.Document{Content: content}
+	proposal := Proposal{
+		Description: docs.ProjectDescription{Summary: "Stream your media"},
+		Links:       []docs.AppLink{{Name: "Home", URL: "https://www.plex.tv", Type: "home"}},
+	}
+
+	changed, err := proposal.Apply(doc)
+	if err != nil || !changed {
+		t.Fatalf("Apply = %v, %v; want true, nil", changed, err)
+	}
+
+	expected := `---
+hide:
+  - tags # keep
+saltbox_automation:
+  sections:
+    inventory: false # comment kept
+  project_description:
+    name: Plex
+    summary: Stream your media
+  app_links:
+    - name: Home
+      url: https://www.plex.tv
+      type: home
+# trailing comment
+tags:
+  - media
+---
+# Plex
+`
+	if doc.Content != expected {
+		t.Errorf("Apply content =\n%s\nwant\n%s", doc.Content, expected)
+	}
+	if doc.Frontmatter.SaltboxAutomation.ProjectDescription.Summary != "Stream your media" {
+		t.Error("Apply did not refresh the parsed frontmatter")
+	}
+
+	// Empty keys are filled in
+	doc = &docs.Document{Content: "---\nsaltbox_automation:\n  project_description:\n  app_links:\n---\n# Plex\n"}
+	if _, err := proposal.Apply(doc); err != nil {
+		t.Fatalf("Apply with empty keys: %v", err)
+	}
+	if got := doc.Frontmatter.SaltboxAutomation; got.ProjectDescription == nil || got.ProjectDescription.Summary != "Stream your media" || len(got.AppLinks) != 1 {
+		t.Errorf("Apply with empty keys =\n%s", doc.Content)
+	}
+
+	// Documents without frontmatter gain one
+	doc = &docs.Document{Content: "# Plex\n"}
+	if _, err := proposal.Apply(doc); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(doc.Content, "---\nsaltbox_automation:\n") || !strings.HasSuffix(doc.Content, "---\n# Plex\n") {
+		t.Errorf("Apply without frontmatter =\n%s", doc.Content)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"text/template"
 
 	"github.com/saltyorg/docs-automation/internal/docs"
+	"github.com/saltyorg/docs-automation/internal/funcs"
 )
 
 // TableGenerator generates overview tables from frontmatter.
@@ -23,7 +24,7 @@ type TableData struct {
 
 // templateFuncs provides helper functions for templates.
 // The icon mapping is defined in the template file itself.
-var templateFuncs = template.FuncMap{}
+var templateFuncs = funcs.Map()
 
 // NewTableGenerator creates a new overview table generator.
 func NewTableGenerator(templatePath string) *TableGenerator {
@@ -50,6 +51,11 @@ func (g *TableGenerator) LoadTemplate() error {
 	return nil
 }
 
+// Template returns the loaded template, or nil if LoadTemplate has not been called.
+func (g *TableGenerator) Template() *template.Template {
+	return g.tmpl
+}
+
 // Generate creates an overview table from app links in frontmatter.
 // Returns empty string if no app links are defined.
 func (g *TableGenerator) Generate(automation *docs.SaltboxAutomationConfig) (string, error) {
@@ -0,0 +1,252 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dependency sources.
+const (
+	DependencyMeta    = "meta"         // meta/main.yml dependencies
+	DependencyInclude = "include_role" // include_role task
+	DependencyImport  = "import_role"  // import_role task
+)
+
+// dependencyTaskKeys maps task keywords that pull in another role to their source.
+var dependencyTaskKeys = map[string]string{
+	"include_role":                 DependencyInclude,
+	"ansible.builtin.include_role": DependencyInclude,
+	"import_role":                  DependencyImport,
+	"ansible.builtin.import_role":  DependencyImport,
+}
+
+// Dependency is a role required by another role.
+type Dependency struct {
+	Name   string // Role name
+	Source string // DependencyMeta, DependencyInclude or DependencyImport
+	File   string // Path relative to the role directory
+	Line   int    // Line number in File
+}
+
+// ParseRoleDependencies reads meta/main.yml dependencies and include_role /
+// import_role tasks of a role. Each role is listed once, at its first
+// occurrence; meta dependencies come first. Role names given as paths are
+// reduced to their base name and templated names are skipped. Files that
+// are not valid YAML are ignored.
+func ParseRoleDependencies(rolePath string) ([]Dependency, error) {
+	var deps []Dependency
+	seen := make(map[string]bool)
+	add := func(dep Dependency) {
+		if dep.Name == "" || strings.Contains(dep.Name, "{{") || seen[dep.Name] {
+			return
+		}
+		seen[dep.Name] = true
+		deps = append(deps, dep)
+	}
+
+	// meta/main.yml dependencies
+	metaPath := filepath.Join(rolePath, "meta", "main.yml")
+	if root, err := readYAMLFile(metaPath); err != nil {
+		return nil, err
+	} else if root != nil {
+		for _, dep := range metaDependencies(root) {
+			dep.File = "meta/main.yml"
+			add(dep)
+		}
+	}
+
+	// include_role / import_role in tasks
+	tasksPath := filepath.Join(rolePath, "tasks")
+	if _, err := os.Stat(tasksPath); os.IsNotExist(err) {
+		return deps, nil
+	}
+
+	var files []string
+	err := filepath.WalkDir(tasksPath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && (strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml")) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		root, err := readYAMLFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if root == nil {
+			continue
+		}
+		rel, _ := filepath.Rel(rolePath, path)
+		for _, dep := range taskDependencies(root) {
+			dep.File = filepath.ToSlash(rel)
+			add(dep)
+		}
+	}
+
+	return deps, nil
+}
+
+// readYAMLFile parses a YAML file into a node tree.
+// It returns nil without error when the file is missing or is not valid YAML.
+func readYAMLFile(path string) (*yaml.Node, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, nil
+	}
+	return &root, nil
+}
+
+// metaDependencies extracts the dependencies list from a meta/main.yml document.
+// Entries are either role names or mappings with a role or name key.
+func metaDependencies(root *yaml.Node) []Dependency {
+	if len(root.Content) == 0 {
+		return nil
+	}
+	list := mappingValue(root.Content[0], "dependencies")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	var deps []Dependency
+	for _, item := range list.Content {
+		var name string
+		switch item.Kind {
+		case yaml.ScalarNode:
+			name = item.Value
+		case yaml.MappingNode:
+			for _, key := range []string{"role", "name"} {
+				if value := mappingValue(item, key); value != nil && value.Kind == yaml.ScalarNode {
+					name = value.Value
+					break
+				}
+			}
+		}
+		deps = append(deps, Dependency{Name: roleBaseName(name), Source: DependencyMeta, Line: item.Line})
+	}
+	return deps
+}
+
+// taskDependencies walks a task file and extracts include_role and import_role names.
+func taskDependencies(root *yaml.Node) []Dependency {
+	var deps []Dependency
+
+	var walk func(*yaml.Node)
+	walk = func(node *yaml.Node) {
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, child := range node.Content {
+				walk(child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if source, ok := dependencyTaskKeys[key.Value]; ok && value.Kind == yaml.MappingNode {
+					if name := mappingValue(value, "name"); name != nil && name.Kind == yaml.ScalarNode {
+						deps = append(deps, Dependency{Name: roleBaseName(name.Value), Source: source, Line: key.Line})
+					}
+					continue
+				}
+				// Recurse into blocks (block/rescue/always)
+				walk(value)
+			}
+		}
+	}
+
+	walk(root)
+	return deps
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// roleBaseName reduces a role reference such as "../roles/docker" or
+// "saltbox.core.docker" to its role name.
+func roleBaseName(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.Contains(name, "{{") {
+		return name
+	}
+	name = filepath.Base(filepath.ToSlash(name))
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// DependencyGraph maps role names to the names of the roles they depend on.
+type DependencyGraph map[string][]string
+
+// Roles returns all roles in the graph, including dependencies without an entry, sorted.
+func (g DependencyGraph) Roles() []string {
+	seen := make(map[string]bool)
+	for role, deps := range g {
+		seen[role] = true
+		for _, dep := range deps {
+			seen[dep] = true
+		}
+	}
+	return mapKeys(seen)
+}
+
+// Roots returns the roles that no other role depends on, sorted.
+func (g DependencyGraph) Roots() []string {
+	required := make(map[string]bool)
+	for _, deps := range g {
+		for _, dep := range deps {
+			required[dep] = true
+		}
+	}
+
+	var roots []string
+	for _, role := range g.Roles() {
+		if !required[role] {
+			roots = append(roots, role)
+		}
+	}
+	return roots
+}
+
+// Dependents returns the roles that depend on role, sorted.
+func (g DependencyGraph) Dependents(role string) []string {
+	var dependents []string
+	for name, deps := range g {
+		for _, dep := range deps {
+			if dep == role {
+				dependents = append(dependents, name)
+				break
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
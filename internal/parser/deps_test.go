@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRoleDependencies(t *testing.T) {
+	rolePath := t.TempDir()
+	writeRoleFile(t, rolePath, "meta/main.yml", `galaxy_info:
+  author: saltbox
+dependencies:
+  - common
+  - role: redis
+    vars:
+      port: 6379
+  - name: ../roles/docker
+`)
+	writeRoleFile(t, rolePath, "tasks/main.yml", `- name: Include docker
+  include_role:
+    name: docker
+- block:
+    - name: Import mariadb
+      ansible.builtin.import_role:
+        name: mariadb
+  rescue:
+    - include_role:
+        name: "{{ fallback_role }}"
+`)
+	writeRoleFile(t, rolePath, "tasks/subtasks/broken.yml", "- name: [unterminated\n")
+
+	deps, err := ParseRoleDependencies(rolePath)
+	if err != nil {
+		t.Fatalf("ParseRoleDependencies returned error: %v", err)
+	}
+
+	var got []string
+	for _, dep := range deps {
+		got = append(got, dep.Name+"/"+dep.Source)
+	}
+	expected := []string{"common/meta", "redis/meta", "docker/meta", "mariadb/import_role"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("dependencies = %v, want %v", got, expected)
+	}
+
+	if deps[3].File != "tasks/main.yml" || deps[3].Line != 6 {
+		t.Errorf("unexpected location for mariadb: %s:%d", deps[3].File, deps[3].Line)
+	}
+}
+
+func TestDependencyGraph(t *testing.T) {
+	graph := DependencyGraph{
+		"authelia": {"redis", "docker"},
+		"redis":    {"docker"},
+		"plex":     nil,
+	}
+
+	if got := graph.Roles(); !reflect.DeepEqual(got, []string{"authelia", "docker", "plex", "redis"}) {
+		t.Errorf("Roles = %v", got)
+	}
+	if got := graph.Roots(); !reflect.DeepEqual(got, []string{"authelia", "plex"}) {
+		t.Errorf("Roots = %v", got)
+	}
+	if got := graph.Dependents("docker"); !reflect.DeepEqual(got, []string{"authelia", "redis"}) {
+		t.Errorf("Dependents = %v", got)
+	}
+}
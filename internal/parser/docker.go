@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -269,6 +270,19 @@ func GetDockerVarType(suffix string) string {
 	}
 }
 
+// ExplainDockerVarType determines the type for a Docker+ variable suffix and
+// returns the decision trace.
+func ExplainDockerVarType(suffix string) *TypeDecision {
+	d := &TypeDecision{}
+	normalized := NormalizeDockerSuffix(suffix)
+
+	typ := GetDockerVarType(normalized)
+	if typ == "string" {
+		return d.decide(RuleDefault, typ, normalized, "", "not a typed docker_container option")
+	}
+	return d.decide(RuleDocker, typ, normalized, "", fmt.Sprintf("docker_container %s option", typ))
+}
+
 // GetDockerVarTypeComment returns a formatted type comment for a docker variable.
 func GetDockerVarTypeComment(suffix string) string {
 	varType := GetDockerVarType(suffix)
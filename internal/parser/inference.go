@@ -2,8 +2,11 @@ package parser
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/saltyorg/docs-automation/internal/config"
@@ -31,55 +34,264 @@ var (
 
 // TypeInferrer handles variable type inference.
 type TypeInferrer struct {
-	cfg *config.TypeInferenceConfig
+	cfg       *config.TypeInferenceConfig
+	exact     []suffixRule
+	overrides []suffixRule
+	patterns  []patternRule
+	usage     map[string]*VariableUsage
+}
+
+// suffixRule maps a configured name suffix to a type.
+type suffixRule struct {
+	suffix string
+	typ    string
+}
+
+// patternRule is a compiled type_inference.patterns entry.
+type patternRule struct {
+	config.TypePattern
+	index int
+	re    *regexp.Regexp
+}
+
+// matches reports whether the rule matches the variable name.
+func (r patternRule) matches(name string) bool {
+	if r.re != nil {
+		return r.re.MatchString(name)
+	}
+	return strings.Contains(name, r.SuffixContains)
+}
+
+// pattern returns the configured matcher text of the rule.
+func (r patternRule) pattern() string {
+	if r.re != nil {
+		return r.Regex
+	}
+	return r.SuffixContains
 }
 
 // NewTypeInferrer creates a new type inferrer with the given configuration.
+// Suffix rules are ordered longest first so the most specific suffix wins,
+// and pattern rules are ordered by priority, keeping config order for ties.
 func NewTypeInferrer(cfg *config.TypeInferenceConfig) *TypeInferrer {
-	return &TypeInferrer{cfg: cfg}
+	t := &TypeInferrer{cfg: cfg}
+	if cfg == nil {
+		return t
+	}
+
+	t.exact = sortedSuffixRules(cfg.Exact)
+	t.overrides = sortedSuffixRules(cfg.Overrides)
+	t.patterns = sortedPatternRules(cfg.Patterns)
+	return t
+}
+
+// SetUsage sets the role usage evidence consulted after config overrides.
+// See ScanRoleUsage.
+func (t *TypeInferrer) SetUsage(usage map[string]*VariableUsage) {
+	t.usage = usage
 }
 
+// sortedSuffixRules returns the suffix rules ordered longest suffix first.
+// Suffixes of equal length can never match the same name, so the
+// alphabetical tie-break only keeps the order stable.
+func sortedSuffixRules(m map[string]string) []suffixRule {
+	rules := make([]suffixRule, 0, len(m))
+	for suffix, typ := range m {
+		rules = append(rules, suffixRule{suffix: suffix, typ: typ})
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if len(rules[i].suffix) != len(rules[j].suffix) {
+			return len(rules[i].suffix) > len(rules[j].suffix)
+		}
+		return rules[i].suffix < rules[j].suffix
+	})
+	return rules
+}
+
+// sortedPatternRules compiles the pattern rules and orders them by priority.
+// Rules with an invalid regex are skipped; config validation reports them.
+func sortedPatternRules(patterns []config.TypePattern) []patternRule {
+	rules := make([]patternRule, 0, len(patterns))
+	for i, pattern := range patterns {
+		rule := patternRule{TypePattern: pattern, index: i}
+		if pattern.Regex != "" {
+			re, err := regexp.Compile(pattern.Regex)
+			if err != nil {
+				continue
+			}
+			rule.re = re
+		}
+		rules = append(rules, rule)
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority > rules[j].Priority
+	})
+	return rules
+}
+
+// matchSuffixRules returns the first (longest) matching rule and the other
+// shorter suffixes that also matched.
+func matchSuffixRules(rules []suffixRule, name string) (*suffixRule, []string) {
+	var match *suffixRule
+	var others []string
+	for i := range rules {
+		if !strings.HasSuffix(name, rules[i].suffix) {
+			continue
+		}
+		if match == nil {
+			match = &rules[i]
+		} else {
+			others = append(others, rules[i].suffix)
+		}
+	}
+	return match, others
+}
+
+// suffixMatchDetail describes a suffix match for the decision trace.
+func suffixMatchDetail(kind, suffix string, others []string) string {
+	detail := fmt.Sprintf("name ends with %s suffix %q", kind, suffix)
+	if len(others) > 0 {
+		detail += fmt.Sprintf(" (longest match, also matched %s)", strings.Join(quoteAll(others), ", "))
+	}
+	return detail
+}
+
+// quoteAll returns each item in Go quoted form.
+func quoteAll(items []string) []string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = strconv.Quote(item)
+	}
+	return quoted
+}
+
+// Inference rule tiers, in the order they are evaluated.
+const (
+	RuleExact    = "exact"    // type_inference.exact suffix match
+	RuleOverride = "override" // type_inference.overrides suffix match
+	RuleUsage    = "usage"    // filter and loop usage in role tasks, templates and handlers
+	RuleValue    = "value"    // literal default value
+	RulePattern  = "pattern"  // type_inference.patterns substring match
+	RuleName     = "name"     // built-in name pattern
+	RuleContext  = "context"  // role_var lookup line context
+	RuleDocker   = "docker"   // docker_container module option type
+	RuleDefault  = "default"  // fallback when no rule matched
+
+	// RuleGlobalOverride is a type configured in global_overrides.variables,
+	// which replaces the inferred type of a role_var suffix.
+	RuleGlobalOverride = "global_override"
+)
+
 // InferType determines the type of a variable based on its name and value.
 func (t *TypeInferrer) InferType(name, value string) string {
-	// First, check for exact suffix matches in config
+	return t.Explain(name, value).Type
+}
+
+// Explain determines the type of a variable and returns the decision trace,
+// recording every rule tier evaluated until one decides the type.
+func (t *TypeInferrer) Explain(name, value string) *TypeDecision {
+	d := &TypeDecision{}
+
+	// First, check for exact suffix matches in config (longest suffix wins)
 	if t.cfg != nil {
-		for suffix, typ := range t.cfg.Exact {
-			if strings.HasSuffix(name, suffix) {
-				return typ
-			}
+		if rule, others := matchSuffixRules(t.exact, name); rule != nil {
+			return d.decide(RuleExact, rule.typ, rule.suffix, "", suffixMatchDetail("exact", rule.suffix, others))
 		}
+		d.skip(RuleExact, fmt.Sprintf("no exact suffix matched (%d rules)", len(t.exact)))
 	}
 
-	// Check for type overrides in config
+	// Check for type overrides in config (longest suffix wins)
 	if t.cfg != nil {
-		for suffix, typ := range t.cfg.Overrides {
-			if strings.HasSuffix(name, suffix) {
-				return typ
+		if rule, others := matchSuffixRules(t.overrides, name); rule != nil {
+			return d.decide(RuleOverride, rule.typ, rule.suffix, "", suffixMatchDetail("override", rule.suffix, others))
+		}
+		d.skip(RuleOverride, fmt.Sprintf("no override suffix matched (%d rules)", len(t.overrides)))
+	}
+
+	// Check how the role uses the variable
+	if t.usage != nil {
+		if usage, ok := t.usage[name]; ok {
+			if typ, agreed := usage.Type(); agreed {
+				first := usage.Evidence[0]
+				return d.decide(RuleUsage, typ, first.Detail, "", usageDetail(usage))
 			}
+			d.skip(RuleUsage, fmt.Sprintf("conflicting usage evidence (%s)", strings.Join(usage.Types(), ", ")))
+		} else {
+			d.skip(RuleUsage, "no filter or loop usage found in role")
 		}
 	}
 
 	// Try to infer from value (pass original value to preserve leading newlines for block detection)
-	if typ := t.inferFromValue(value); typ != "" {
-		return typ
+	if typ, reason := t.inferFromValue(value); typ != "" {
+		return d.decide(RuleValue, typ, "", value, reason)
 	}
+	d.skip(RuleValue, "value did not determine a type")
 
 	// Check pattern-based inference from config
 	if t.cfg != nil {
-		for _, pattern := range t.cfg.Patterns {
-			if strings.Contains(name, pattern.SuffixContains) {
-				return pattern.Type
-			}
+		if rule := t.matchPattern(name); rule != nil {
+			return d.decide(RulePattern, rule.Type, rule.pattern(), "",
+				fmt.Sprintf("name %s (patterns[%d], priority %d)", patternVerb(*rule), rule.index, rule.Priority))
 		}
+		d.skip(RulePattern, fmt.Sprintf("no pattern matched (%d rules)", len(t.patterns)))
 	}
 
 	// Fallback pattern-based inference
-	return t.inferFromNamePattern(name)
+	if typ, suffix := t.inferFromNamePattern(name); suffix != "" {
+		return d.decide(RuleName, typ, suffix, "", fmt.Sprintf("name ends with built-in suffix %q", suffix))
+	}
+
+	// Default to string
+	return d.decide(RuleDefault, types.String, "", "", "no rule matched")
+}
+
+// usageDetail describes agreeing usage evidence for the decision trace.
+func usageDetail(usage *VariableUsage) string {
+	first := usage.Evidence[0]
+	detail := fmt.Sprintf("used as %s at %s", first.Detail, first.Location())
+	if more := len(usage.Evidence) - 1; more > 0 {
+		detail += fmt.Sprintf(" (+%d more)", more)
+	}
+	return detail
+}
+
+// matchPattern returns the highest precedence pattern rule matching name, or nil.
+func (t *TypeInferrer) matchPattern(name string) *patternRule {
+	for i := range t.patterns {
+		if t.patterns[i].matches(name) {
+			return &t.patterns[i]
+		}
+	}
+	return nil
+}
+
+// patternVerb describes how a pattern rule matched, e.g. `contains "_port"`.
+func patternVerb(rule patternRule) string {
+	if rule.re != nil {
+		return fmt.Sprintf("matches regex %q", rule.Regex)
+	}
+	return fmt.Sprintf("contains %q", rule.SuffixContains)
+}
+
+// decide records the matching rule and sets the final type.
+func (d *TypeDecision) decide(rule, typ, pattern, value, detail string) *TypeDecision {
+	d.Steps = append(d.Steps, TypeStep{Rule: rule, Matched: true, Pattern: pattern, Detail: detail})
+	d.Type = typ
+	d.Rule = rule
+	d.Pattern = pattern
+	d.Value = value
+	return d
+}
+
+// skip records a rule tier that was evaluated without matching.
+func (d *TypeDecision) skip(rule, detail string) {
+	d.Steps = append(d.Steps, TypeStep{Rule: rule, Detail: detail})
 }
 
 // inferFromValue attempts to determine type from the raw value.
 // This follows Python's approach: infer primarily from value type, not name patterns.
-func (t *TypeInferrer) inferFromValue(value string) string {
+// It returns the type and a short description of the value rule that matched.
+func (t *TypeInferrer) inferFromValue(value string) (string, string) {
 	// Check for multiline values first
 	if strings.Contains(value, "\n") {
 		lines := strings.Split(value, "\n")
@@ -91,17 +303,17 @@ func (t *TypeInferrer) inferFromValue(value string) string {
 			trimmedSecond := strings.TrimSpace(secondLine)
 			// Block list starts with -
 			if strings.HasPrefix(trimmedSecond, "-") {
-				return types.List
+				return types.List, "block sequence"
 			}
 			// Block dict has key: value pairs
 			if strings.Contains(trimmedSecond, ":") && !strings.HasPrefix(trimmedSecond, "#") {
-				return types.Dict
+				return types.Dict, "block mapping"
 			}
 		}
 
 		// Block list indicator
 		if strings.HasPrefix(firstLine, "-") {
-			return types.List
+			return types.List, "block sequence"
 		}
 	}
 
@@ -109,117 +321,86 @@ func (t *TypeInferrer) inferFromValue(value string) string {
 
 	// Null values
 	if trimmedValue == "" || trimmedValue == "~" || trimmedValue == "null" {
-		return "null"
+		return "null", "empty or null value"
 	}
 
 	// Empty strings (quoted)
 	if trimmedValue == "\"\"" || trimmedValue == "''" {
-		return types.String
+		return types.String, "quoted empty string"
 	}
 
 	// Boolean literals
 	if boolTrueRe.MatchString(trimmedValue) || boolFalseRe.MatchString(trimmedValue) {
-		return types.Bool
+		return types.Bool, "boolean literal"
 	}
 
 	// Integer
 	if intRe.MatchString(trimmedValue) {
-		return types.Int
+		return types.Int, "integer literal"
 	}
 
 	// Float
 	if floatRe.MatchString(trimmedValue) {
-		return "float"
+		return "float", "float literal"
 	}
 
 	// List (flow style)
 	if listRe.MatchString(trimmedValue) {
-		return types.List
+		return types.List, "flow sequence"
 	}
 
 	// Dict (flow style)
 	if dictRe.MatchString(trimmedValue) {
-		return types.Dict
+		return types.Dict, "flow mapping"
 	}
 
 	// Block list (starts with -)
 	if strings.HasPrefix(trimmedValue, "-") || strings.HasPrefix(trimmedValue, "  -") {
-		return types.List
+		return types.List, "block sequence"
 	}
 
 	// Quoted strings or Jinja expressions are strings
 	if strings.HasPrefix(trimmedValue, "\"") || strings.HasPrefix(trimmedValue, "'") ||
 		strings.Contains(trimmedValue, "{{") {
-		return types.String
+		return types.String, "quoted string or Jinja expression"
 	}
 
 	// Default: treat as string (matches Python behavior for unknown types)
-	return types.String
+	return types.String, "plain scalar"
+}
+
+// nameSuffixRule maps built-in variable name suffixes to a type.
+type nameSuffixRule struct {
+	typ      string
+	suffixes []string
+}
+
+// nameSuffixRules are the built-in name patterns, checked in order.
+var nameSuffixRules = []nameSuffixRule{
+	{"bool (true/false)", []string{"_enabled", "_proxy", "_insecure"}},
+	{types.String, []string{"_domain", "_subdomain", "_url", "_path", "_location", "_folder", "_name",
+		"_container", "_image", "_tag", "_repo", "_record", "_zone", "_token", "_theme"}},
+	{types.StringNumber, []string{"_port", "_timeout"}},
+	{types.StringHTTPHTTPS, []string{"_scheme"}},
+	{types.List, []string{"_list", "_ports", "_volumes", "_networks", "_labels", "_devices", "_addons", "_instances"}},
+	{types.Dict, []string{"_envs", "_dict", "_options", "_labels"}},
 }
 
 // inferFromNamePattern infers type from variable name patterns.
-func (t *TypeInferrer) inferFromNamePattern(name string) string {
+// It returns the type and the suffix that matched, or an empty suffix if none did.
+func (t *TypeInferrer) inferFromNamePattern(name string) (string, string) {
 	lower := strings.ToLower(name)
 
-	// Boolean patterns
-	if strings.HasSuffix(lower, "_enabled") ||
-		strings.HasSuffix(lower, "_proxy") ||
-		strings.HasSuffix(lower, "_insecure") {
-		return "bool (true/false)"
-	}
-
-	// String patterns
-	if strings.HasSuffix(lower, "_domain") ||
-		strings.HasSuffix(lower, "_subdomain") ||
-		strings.HasSuffix(lower, "_url") ||
-		strings.HasSuffix(lower, "_path") ||
-		strings.HasSuffix(lower, "_location") ||
-		strings.HasSuffix(lower, "_folder") ||
-		strings.HasSuffix(lower, "_name") ||
-		strings.HasSuffix(lower, "_container") ||
-		strings.HasSuffix(lower, "_image") ||
-		strings.HasSuffix(lower, "_tag") ||
-		strings.HasSuffix(lower, "_repo") ||
-		strings.HasSuffix(lower, "_record") ||
-		strings.HasSuffix(lower, "_zone") ||
-		strings.HasSuffix(lower, "_token") ||
-		strings.HasSuffix(lower, "_theme") {
-		return types.String
-	}
-
-	// Numeric patterns
-	if strings.HasSuffix(lower, "_port") ||
-		strings.HasSuffix(lower, "_timeout") {
-		return types.StringNumber
-	}
-
-	// Scheme pattern
-	if strings.HasSuffix(lower, "_scheme") {
-		return types.StringHTTPHTTPS
-	}
-
-	// List patterns
-	if strings.HasSuffix(lower, "_list") ||
-		strings.HasSuffix(lower, "_ports") ||
-		strings.HasSuffix(lower, "_volumes") ||
-		strings.HasSuffix(lower, "_networks") ||
-		strings.HasSuffix(lower, "_labels") ||
-		strings.HasSuffix(lower, "_devices") ||
-		strings.HasSuffix(lower, "_addons") ||
-		strings.HasSuffix(lower, "_instances") {
-		return types.List
-	}
-
-	// Dict patterns
-	if strings.HasSuffix(lower, "_envs") ||
-		strings.HasSuffix(lower, "_dict") ||
-		strings.HasSuffix(lower, "_options") ||
-		strings.HasSuffix(lower, "_labels") {
-		return types.Dict
+	for _, rule := range nameSuffixRules {
+		for _, suffix := range rule.suffixes {
+			if strings.HasSuffix(lower, suffix) {
+				return rule.typ, suffix
+			}
+		}
 	}
 
 	// Default to string
-	return types.String
+	return types.String, ""
 }
 
 // ExtractRoleVarLookups finds all role_var lookup suffixes in a value.
@@ -239,50 +420,121 @@ func ExtractRoleVarLookups(value string) []string {
 // InferRoleVarType determines the type for a role_var lookup suffix.
 // This uses the suffix name and line context to infer the type, matching Python's TYPE_INFERENCE_RULES.
 func InferRoleVarType(suffix, line string) string {
+	return ExplainRoleVarType(suffix, line).Type
+}
+
+// ExplainRoleVarType determines the type for a role_var lookup suffix and
+// returns the decision trace.
+func ExplainRoleVarType(suffix, line string) *TypeDecision {
+	d := &TypeDecision{}
+
 	// Exact suffix matches first (order matters - most specific first)
-	if suffix == "_depends_on_healthchecks" {
-		return types.StringTrueFalse
-	}
-	if suffix == "_depends_on_delay" {
-		return types.StringNumber
-	}
-	if suffix == "_depends_on" {
-		return types.String
+	switch suffix {
+	case "_depends_on_healthchecks":
+		return d.decide(RuleExact, types.StringTrueFalse, suffix, "", "built-in exact suffix")
+	case "_depends_on_delay":
+		return d.decide(RuleExact, types.StringNumber, suffix, "", "built-in exact suffix")
+	case "_depends_on":
+		return d.decide(RuleExact, types.String, suffix, "", "built-in exact suffix")
 	}
+	d.skip(RuleExact, "no built-in exact suffix matched")
 
 	// Pattern matches on suffix
-	if strings.Contains(suffix, "_scheme") {
-		return types.StringHTTPHTTPS
-	}
-	if strings.Contains(suffix, "_enabled") || strings.Contains(suffix, "_proxy") {
-		return types.Bool
-	}
-	if strings.Contains(suffix, "_domain") || strings.Contains(suffix, "_subdomain") || strings.Contains(suffix, "_url") {
-		return types.String
-	}
-	if strings.Contains(suffix, "_port") || strings.Contains(suffix, "_timeout") {
-		return types.StringNumber
+	for _, rule := range roleVarPatternRules {
+		for _, pattern := range rule.suffixes {
+			if strings.Contains(suffix, pattern) {
+				return d.decide(RuleName, rule.typ, pattern, "", fmt.Sprintf("suffix contains %q", pattern))
+			}
+		}
 	}
+	d.skip(RuleName, "no built-in suffix pattern matched")
 
 	// Line context checks
-	if strings.Contains(line, "| bool") {
-		return types.Bool
-	}
-	if defaultQuotedRe.MatchString(line) {
-		return types.String
+	for _, rule := range roleVarContextRules {
+		if rule.match(line) {
+			return d.decide(RuleContext, rule.typ, rule.pattern, line, fmt.Sprintf("lookup line contains %s", rule.pattern))
+		}
 	}
-	if defaultBoolRe.MatchString(line) {
-		return types.Bool
+	d.skip(RuleContext, "lookup line gave no type hint")
+
+	// Default to string (matches Python behavior)
+	return d.decide(RuleDefault, types.String, "", "", "no rule matched")
+}
+
+// roleVarPatternRules are the built-in suffix patterns for role_var lookups, checked in order.
+var roleVarPatternRules = []nameSuffixRule{
+	{types.StringHTTPHTTPS, []string{"_scheme"}},
+	{types.Bool, []string{"_enabled", "_proxy"}},
+	{types.String, []string{"_domain", "_subdomain", "_url"}},
+	{types.StringNumber, []string{"_port", "_timeout"}},
+}
+
+// roleVarContextRule infers a role_var type from the text of the lookup line.
+type roleVarContextRule struct {
+	typ     string
+	pattern string
+	match   func(line string) bool
+}
+
+// roleVarContextRules are the line context checks for role_var lookups, checked in order.
+var roleVarContextRules = []roleVarContextRule{
+	{types.Bool, "| bool", func(line string) bool { return strings.Contains(line, "| bool") }},
+	{types.String, "quoted default=", defaultQuotedRe.MatchString},
+	{types.Bool, "boolean default=", defaultBoolRe.MatchString},
+	{types.DictOmit, "default={} or default=omit", defaultDictOmitRe.MatchString},
+	{types.List, "default=[]", defaultListRe.MatchString},
+}
+
+// FindRoleVarOccurrences returns every inventory line that looks up suffix via
+// role_var, together with the type decision for that line.
+func FindRoleVarOccurrences(inventoryPath, suffix string) ([]RoleVarOccurrence, error) {
+	file, err := os.Open(inventoryPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
 	}
-	if defaultDictOmitRe.MatchString(line) {
-		return types.DictOmit
+	defer file.Close()
+
+	var occurrences []RoleVarOccurrence
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		for _, match := range roleVarLookupRe.FindAllStringSubmatch(line, -1) {
+			if len(match) > 1 && match[1] == suffix {
+				occurrences = append(occurrences, RoleVarOccurrence{
+					LineNumber: lineNum,
+					Line:       line,
+					Decision:   ExplainRoleVarType(suffix, line),
+				})
+				break
+			}
+		}
 	}
-	if defaultListRe.MatchString(line) {
-		return types.List
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
 
-	// Default to string (matches Python behavior)
-	return types.String
+	return occurrences, nil
+}
+
+// EffectiveRoleVarOccurrence returns the occurrence whose type is used in the docs.
+// This mirrors ScanInventoryForRoleVarLookups: the first non-string type wins,
+// otherwise the last occurrence is used.
+func EffectiveRoleVarOccurrence(occurrences []RoleVarOccurrence) *RoleVarOccurrence {
+	if len(occurrences) == 0 {
+		return nil
+	}
+	for i := range occurrences {
+		if occurrences[i].Decision.Type != types.String {
+			return &occurrences[i]
+		}
+	}
+	return &occurrences[len(occurrences)-1]
 }
 
 // ScanInventoryForRoleVarLookups scans the inventory file for all role_var lookups.
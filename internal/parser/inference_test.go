@@ -0,0 +1,184 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/types"
+)
+
+func TestExplain_RecordsTrace(t *testing.T) {
+	cfg := &config.TypeInferenceConfig{
+		Exact:     map[string]string{"_web_port": types.StringNumber},
+		Overrides: map[string]string{},
+	}
+	typeInfer := NewTypeInferrer(cfg)
+
+	decision := typeInfer.Explain("plex_role_web_port", `"32400"`)
+	if decision.Type != types.StringNumber || decision.Rule != RuleExact || decision.Pattern != "_web_port" {
+		t.Fatalf("unexpected decision: %+v", decision)
+	}
+	if len(decision.Steps) != 1 || !decision.Steps[0].Matched {
+		t.Fatalf("expected a single matched step, got %+v", decision.Steps)
+	}
+
+	decision = typeInfer.Explain("plex_role_web_subdomain", `"{{ plex_name }}"`)
+	if decision.Type != types.String || decision.Rule != RuleValue {
+		t.Fatalf("unexpected decision: %+v", decision)
+	}
+	if decision.Value != `"{{ plex_name }}"` {
+		t.Errorf("expected triggering value to be recorded, got %q", decision.Value)
+	}
+	if len(decision.Steps) != 3 || decision.Steps[0].Matched || decision.Steps[1].Matched || !decision.Steps[2].Matched {
+		t.Errorf("expected exact and override misses followed by value match, got %+v", decision.Steps)
+	}
+}
+
+func TestExplainRoleVarType(t *testing.T) {
+	tests := []struct {
+		suffix  string
+		line    string
+		typ     string
+		rule    string
+		pattern string
+	}{
+		{"_depends_on_delay", "", types.StringNumber, RuleExact, "_depends_on_delay"},
+		{"_traefik_enabled", "", types.Bool, RuleName, "_enabled"},
+		{"_docker_envs_custom", "lookup('role_var', '_docker_envs_custom', default={})", types.DictOmit, RuleContext, "default={} or default=omit"},
+		{"_something", "lookup('role_var', '_something')", types.String, RuleDefault, ""},
+	}
+
+	for _, tt := range tests {
+		decision := ExplainRoleVarType(tt.suffix, tt.line)
+		if decision.Type != tt.typ || decision.Rule != tt.rule || decision.Pattern != tt.pattern {
+			t.Errorf("ExplainRoleVarType(%q) = %s/%s/%s, want %s/%s/%s",
+				tt.suffix, decision.Type, decision.Rule, decision.Pattern, tt.typ, tt.rule, tt.pattern)
+		}
+		if InferRoleVarType(tt.suffix, tt.line) != decision.Type {
+			t.Errorf("InferRoleVarType(%q) disagrees with ExplainRoleVarType", tt.suffix)
+		}
+	}
+}
+
+func TestFindRoleVarOccurrences(t *testing.T) {
+	inventory := filepath.Join(t.TempDir(), "all.yml")
+	content := `a: "{{ lookup('role_var', '_thing') }}"
+b: "{{ lookup('role_var', '_other', default='') }}"
+c: "{{ lookup('role_var', '_thing', default=[]) }}"
+`
+	if err := os.WriteFile(inventory, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing inventory: %v", err)
+	}
+
+	occurrences, err := FindRoleVarOccurrences(inventory, "_thing")
+	if err != nil {
+		t.Fatalf("FindRoleVarOccurrences returned error: %v", err)
+	}
+	if len(occurrences) != 2 || occurrences[0].LineNumber != 1 || occurrences[1].LineNumber != 3 {
+		t.Fatalf("unexpected occurrences: %+v", occurrences)
+	}
+
+	effective := EffectiveRoleVarOccurrence(occurrences)
+	if effective.LineNumber != 3 || effective.Decision.Type != types.List {
+		t.Errorf("expected line 3 list to win, got line %d %s", effective.LineNumber, effective.Decision.Type)
+	}
+
+	lookups, err := ScanInventoryForRoleVarLookups(inventory, nil)
+	if err != nil {
+		t.Fatalf("ScanInventoryForRoleVarLookups returned error: %v", err)
+	}
+	if lookups["_thing"] != effective.Decision.Type {
+		t.Errorf("effective occurrence %q disagrees with scan result %q", effective.Decision.Type, lookups["_thing"])
+	}
+}
+
+func TestInferType_LongestSuffixWins(t *testing.T) {
+	cfg := &config.TypeInferenceConfig{
+		Exact: map[string]string{
+			"_port":     types.Int,
+			"_web_port": types.StringNumber,
+			"_b_port":   types.Bool,
+		},
+		Overrides: map[string]string{"_ort": types.List},
+	}
+
+	// Repeat to catch map iteration order leaking into the result
+	for i := 0; i < 50; i++ {
+		decision := NewTypeInferrer(cfg).Explain("plex_role_web_port", "")
+		if decision.Type != types.StringNumber || decision.Pattern != "_web_port" {
+			t.Fatalf("run %d: expected _web_port to win, got %s (%s)", i, decision.Type, decision.Pattern)
+		}
+	}
+
+	decision := NewTypeInferrer(cfg).Explain("plex_role_port", "")
+	if decision.Pattern != "_port" {
+		t.Errorf("expected _port to match, got %q", decision.Pattern)
+	}
+}
+
+func TestInferType_PatternPriorityAndRegex(t *testing.T) {
+	cfg := &config.TypeInferenceConfig{
+		Patterns: []config.TypePattern{
+			{SuffixContains: "_docker_", Type: types.String},
+			{Regex: `_docker_(envs|labels)_`, Type: types.Dict, Priority: 10},
+			{Regex: `^[a-z]+_role_.*_ports?$`, Type: types.List},
+		},
+	}
+	typeInfer := NewTypeInferrer(cfg)
+
+	tests := []struct {
+		name     string
+		expected string
+		pattern  string
+	}{
+		{"plex_role_docker_envs_custom", types.Dict, `_docker_(envs|labels)_`},
+		{"plex_role_docker_image_repo", types.String, "_docker_"},
+		{"plex_role_web_ports", types.List, `^[a-z]+_role_.*_ports?$`},
+	}
+
+	for _, tt := range tests {
+		rule := typeInfer.matchPattern(tt.name)
+		if rule == nil {
+			t.Errorf("matchPattern(%q) matched nothing", tt.name)
+			continue
+		}
+		if rule.Type != tt.expected || rule.pattern() != tt.pattern {
+			t.Errorf("matchPattern(%q) = %s/%q, want %s/%q", tt.name, rule.Type, rule.pattern(), tt.expected, tt.pattern)
+		}
+	}
+}
+
+func TestCheckTypeRules(t *testing.T) {
+	cfg := &config.TypeInferenceConfig{
+		Exact:     map[string]string{"_port": types.StringNumber},
+		Overrides: map[string]string{"_web_port": types.Int, "_timeout": types.Int},
+		Patterns: []config.TypePattern{
+			{SuffixContains: "_docker", Type: types.String},
+			{SuffixContains: "_docker_envs", Type: types.Dict},
+			{SuffixContains: "_web_host", Type: types.String},
+			{SuffixContains: "_host", Type: types.Bool},
+			{SuffixContains: "_path", Type: types.String},
+			{SuffixContains: "_paths", Type: types.List, Priority: 5},
+			{Regex: `_ids?$`, Type: types.List},
+			{Regex: `_ids?$`, Type: types.String},
+		},
+	}
+
+	var got []string
+	for _, issue := range CheckTypeRules(cfg) {
+		got = append(got, issue.Rule+" "+issue.Kind)
+	}
+
+	expected := []string{
+		"overrides._web_port shadowed",
+		"patterns[1] shadowed",
+		"patterns[3] ambiguous",
+		"patterns[7] shadowed",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("CheckTypeRules =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
@@ -3,6 +3,7 @@ package parser
 import (
 	"bufio"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 )
@@ -53,6 +54,7 @@ func (p *Parser) ParseFile(path string) (*RoleInfo, error) {
 	role := &RoleInfo{
 		Name:         p.roleName,
 		RepoType:     p.repoType,
+		Path:         filepath.Dir(filepath.Dir(path)),
 		Sections:     make(map[string]*Section),
 		SectionOrder: []string{},
 		AllVariables: []Variable{},
@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SandboxTagPrefix is prepended to sandbox tags in install commands.
+const SandboxTagPrefix = "sandbox-"
+
+var (
+	// installCommandRe matches "sb install" commands, capturing the comma-separated tags.
+	installCommandRe = regexp.MustCompile(`\bsb install ([A-Za-z0-9_,-]+)`)
+
+	// ignoredTags are tags that do not select a role.
+	ignoredTags = map[string]bool{
+		"always": true,
+		"never":  true,
+	}
+)
+
+// PlaybookTags maps role names to the tags they are included with in a playbook.
+type PlaybookTags map[string][]string
+
+// ParsePlaybookTags reads the roles of a playbook (saltbox.yml, sandbox.yml)
+// and the tags each is included with. Both "roles:" entries and
+// include_role/import_role tasks are read. A missing playbook yields no tags;
+// a playbook that is not valid YAML is an error.
+func ParsePlaybookTags(path string) (PlaybookTags, error) {
+	tags := make(PlaybookTags)
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return tags, nil
+	}
+	if err != nil {
+		return tags, err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return tags, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(root.Content) == 0 {
+		return tags, nil
+	}
+
+	plays := root.Content[0]
+	if plays.Kind != yaml.SequenceNode {
+		return tags, nil
+	}
+
+	for _, play := range plays.Content {
+		if roles := mappingValue(play, "roles"); roles != nil && roles.Kind == yaml.SequenceNode {
+			for _, entry := range roles.Content {
+				tags.addEntry(entry)
+			}
+		}
+		for _, key := range []string{"pre_tasks", "tasks", "post_tasks"} {
+			if tasks := mappingValue(play, key); tasks != nil && tasks.Kind == yaml.SequenceNode {
+				for _, task := range tasks.Content {
+					for taskKey := range dependencyTaskKeys {
+						if ref := mappingValue(task, taskKey); ref != nil {
+							tags.addTaskEntry(task, ref)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// addEntry records a roles: entry, either a role name or a mapping with a role (or name) and tags.
+func (p PlaybookTags) addEntry(entry *yaml.Node) {
+	switch entry.Kind {
+	case yaml.ScalarNode:
+		p.add(entry.Value, nil)
+	case yaml.MappingNode:
+		name := mappingValue(entry, "role")
+		if name == nil {
+			name = mappingValue(entry, "name")
+		}
+		if name == nil || name.Kind != yaml.ScalarNode {
+			return
+		}
+		p.add(name.Value, nodeStrings(mappingValue(entry, "tags")))
+	}
+}
+
+// addTaskEntry records an include_role/import_role task; tags may be on the task or the role reference.
+func (p PlaybookTags) addTaskEntry(task, ref *yaml.Node) {
+	name := mappingValue(ref, "name")
+	if name == nil || name.Kind != yaml.ScalarNode {
+		return
+	}
+	tags := nodeStrings(mappingValue(task, "tags"))
+	tags = append(tags, nodeStrings(mappingValue(ref, "apply"))...)
+	p.add(name.Value, tags)
+}
+
+// add records the tags of a role, keeping each tag once and in order.
+func (p PlaybookTags) add(role string, tags []string) {
+	role = roleBaseName(role)
+	if role == "" || strings.Contains(role, "{{") {
+		return
+	}
+	if _, ok := p[role]; !ok {
+		p[role] = []string{}
+	}
+	for _, tag := range tags {
+		if tag == "" || ignoredTags[tag] || containsString(p[role], tag) {
+			continue
+		}
+		p[role] = append(p[role], tag)
+	}
+}
+
+// nodeStrings returns the scalar values of a tags node, which may be a
+// single string, a comma-separated string or a list.
+func nodeStrings(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+
+	var values []string
+	switch node.Kind {
+	case yaml.ScalarNode:
+		for _, value := range strings.Split(node.Value, ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				values = append(values, strings.TrimSpace(item.Value))
+			}
+		}
+	case yaml.MappingNode:
+		// apply: {tags: [...]}
+		return nodeStrings(mappingValue(node, "tags"))
+	}
+	return values
+}
+
+// Tags returns the tags of a role, or nil if the playbook does not include it.
+func (p PlaybookTags) Tags(role string) []string {
+	return p[role]
+}
+
+// PrimaryTag returns the tag used to install a role: the tag equal to the
+// role name, otherwise the first tag no other role uses, otherwise the first
+// tag. Roles without tags fall back to the role name.
+func (p PlaybookTags) PrimaryTag(role string) string {
+	tags := p[role]
+	if len(tags) == 0 {
+		return role
+	}
+	if containsString(tags, role) {
+		return role
+	}
+	for _, tag := range tags {
+		if len(p.RolesWithTag(tag)) == 1 {
+			return tag
+		}
+	}
+	return tags[0]
+}
+
+// RolesWithTag returns the roles included with tag, sorted.
+func (p PlaybookTags) RolesWithTag(tag string) []string {
+	var roles []string
+	for role, tags := range p {
+		if containsString(tags, tag) {
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// HasTag reports whether any role is included with tag.
+func (p PlaybookTags) HasTag(tag string) bool {
+	for _, tags := range p {
+		if containsString(tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// InstallTag is a tag referenced by an "sb install" command.
+type InstallTag struct {
+	Tag  string
+	Line int
+}
+
+// ExtractInstallTags returns the tags referenced by "sb install" commands in
+// content, each with its 1-based line number.
+func ExtractInstallTags(content string) []InstallTag {
+	var result []InstallTag
+	for i, line := range strings.Split(content, "\n") {
+		for _, match := range installCommandRe.FindAllStringSubmatch(line, -1) {
+			for _, tag := range strings.Split(match[1], ",") {
+				if tag != "" {
+					result = append(result, InstallTag{Tag: tag, Line: i + 1})
+				}
+			}
+		}
+	}
+	return result
+}
+
+// containsString reports whether items contains s.
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePlaybookTags(t *testing.T) {
+	dir := t.TempDir()
+	writeRoleFile(t, dir, "saltbox.yml", `---
+- hosts: localhost
+  roles:
+    - { role: pre_tasks, tags: ['always'] }
+    - { role: plex, tags: ['plex', 'mediabox'] }
+    - role: authelia
+      tags:
+        - authelia-role
+        - core
+    - { role: sonarr, tags: 'sonarr,arrs' }
+    - { role: radarr, tags: ['arrs'] }
+    - notags
+  tasks:
+    - name: Import docker
+      import_role:
+        name: docker
+      tags: docker
+`)
+
+	tags, err := ParsePlaybookTags(filepath.Join(dir, "saltbox.yml"))
+	if err != nil {
+		t.Fatalf("ParsePlaybookTags returned error: %v", err)
+	}
+
+	expected := PlaybookTags{
+		"pre_tasks": {},
+		"plex":      {"plex", "mediabox"},
+		"authelia":  {"authelia-role", "core"},
+		"sonarr":    {"sonarr", "arrs"},
+		"radarr":    {"arrs"},
+		"notags":    {},
+		"docker":    {"docker"},
+	}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("ParsePlaybookTags = %v, want %v", tags, expected)
+	}
+
+	primary := map[string]string{
+		"plex":     "plex",
+		"authelia": "authelia-role",
+		"radarr":   "arrs",
+		"notags":   "notags",
+		"missing":  "missing",
+	}
+	for role, want := range primary {
+		if got := tags.PrimaryTag(role); got != want {
+			t.Errorf("PrimaryTag(%q) = %q, want %q", role, got, want)
+		}
+	}
+
+	if !tags.HasTag("mediabox") || tags.HasTag("always") || tags.HasTag("authelia") {
+		t.Error("HasTag returned wrong result")
+	}
+	if got := tags.RolesWithTag("arrs"); !reflect.DeepEqual(got, []string{"radarr", "sonarr"}) {
+		t.Errorf("RolesWithTag = %v", got)
+	}
+}
+
+func TestParsePlaybookTags_Missing(t *testing.T) {
+	tags, err := ParsePlaybookTags(filepath.Join(t.TempDir(), "missing.yml"))
+	if err != nil || len(tags) != 0 {
+		t.Errorf("expected no tags and no error, got %v, %v", tags, err)
+	}
+}
+
+func TestParsePlaybookTags_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	writeRoleFile(t, dir, "saltbox.yml", "- hosts: localhost\n  roles: [plex\n")
+	path := filepath.Join(dir, "saltbox.yml")
+
+	if _, err := ParsePlaybookTags(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("expected an error naming the playbook, got %v", err)
+	}
+}
+
+func TestExtractInstallTags(t *testing.T) {
+	content := "# Plex\n\n```shell\nsb install plex\n```\n\nOr `sb install sandbox-foo,sonarr`.\n"
+
+	expected := []InstallTag{{"plex", 4}, {"sandbox-foo", 7}, {"sonarr", 7}}
+	if got := ExtractInstallTags(content); !reflect.DeepEqual(got, expected) {
+		t.Errorf("ExtractInstallTags = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxResolveDepth limits how many references are followed, guarding against cycles.
+const maxResolveDepth = 10
+
+var (
+	// jinjaExprRe matches a {{ ... }} expression.
+	jinjaExprRe = regexp.MustCompile(`\{\{-?\s*(.*?)\s*-?\}\}`)
+
+	// identRe matches a plain variable reference.
+	identRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// roleVarCallRe matches a role_var lookup call, capturing the suffix and remaining arguments.
+	roleVarCallRe = regexp.MustCompile(`^lookup\s*\(\s*['"]role_var['"]\s*,\s*['"]([^'"]+)['"]\s*(.*)\)$`)
+
+	// roleVarDefaultArgRe matches the default= argument of a role_var lookup.
+	roleVarDefaultArgRe = regexp.MustCompile(`default\s*=\s*('[^']*'|"[^"]*"|[^,\s)]+)`)
+
+	// defaultFilterRe matches a default() filter, capturing its first argument.
+	defaultFilterRe = regexp.MustCompile(`^(?:default|d)\s*\(\s*('[^']*'|"[^"]*"|[^,\s)]+)\s*(?:,\s*(?:true|True))?\s*\)$`)
+)
+
+// Resolver statically evaluates simple Jinja default expressions against a
+// role's own defaults and the inventory. It understands plain variable
+// references, role_var lookups and default() filters; anything else is left
+// unresolved.
+type Resolver struct {
+	roleName  string
+	vars      map[string]string // role default name -> decoded scalar value
+	inventory map[string]string // inventory name -> decoded scalar value
+}
+
+// NewResolver creates a resolver for a role.
+// inventory holds decoded scalar values, see LoadInventoryValues.
+func NewResolver(roleName string, roleVars []Variable, inventory map[string]string) *Resolver {
+	vars := make(map[string]string, len(roleVars))
+	for _, v := range roleVars {
+		if value, ok := decodeScalar(v.RawValue); ok {
+			vars[v.Name] = value
+		}
+	}
+	return &Resolver{roleName: roleName, vars: vars, inventory: inventory}
+}
+
+// LoadInventoryValues reads the top-level scalar values of an inventory file.
+// A missing file yields no values.
+func LoadInventoryValues(path string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return values, nil
+		}
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return values, nil
+	}
+
+	mapping := root.Content[0]
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if key.Kind == yaml.ScalarNode && value.Kind == yaml.ScalarNode {
+			values[key.Value] = value.Value
+		}
+	}
+	return values, nil
+}
+
+// Resolve returns the effective value of a raw default value.
+// It returns false when the value contains no Jinja expression or when any
+// expression in it cannot be resolved statically.
+func (r *Resolver) Resolve(raw string) (string, bool) {
+	value, ok := decodeScalar(raw)
+	if !ok || !strings.Contains(value, "{{") {
+		return "", false
+	}
+	return r.render(value, 0)
+}
+
+// Value returns the effective value of a raw default value: the decoded
+// scalar when it holds no Jinja expression, otherwise its resolved value.
+func (r *Resolver) Value(raw string) (string, bool) {
+	value, ok := decodeScalar(raw)
+	if !ok || !strings.Contains(value, "{{") {
+		return value, ok
+	}
+	return r.render(value, 0)
+}
+
+// render substitutes every {{ expression }} in value.
+func (r *Resolver) render(value string, depth int) (string, bool) {
+	if depth > maxResolveDepth {
+		return "", false
+	}
+
+	ok := true
+	result := jinjaExprRe.ReplaceAllStringFunc(value, func(match string) string {
+		expr := jinjaExprRe.FindStringSubmatch(match)[1]
+		resolved, found := r.eval(expr, depth)
+		if !found {
+			ok = false
+		}
+		return resolved
+	})
+	if !ok {
+		return "", false
+	}
+	return result, true
+}
+
+// eval evaluates an expression made of a primary value and default() filters.
+func (r *Resolver) eval(expr string, depth int) (string, bool) {
+	parts := splitFilters(expr)
+	value, defined := r.primary(strings.TrimSpace(parts[0]), depth)
+
+	for _, filter := range parts[1:] {
+		match := defaultFilterRe.FindStringSubmatch(strings.TrimSpace(filter))
+		if match == nil {
+			return "", false
+		}
+		if !defined {
+			value, defined = r.primary(match[1], depth)
+		}
+	}
+
+	return value, defined
+}
+
+// primary evaluates a literal, a variable reference or a role_var lookup.
+func (r *Resolver) primary(expr string, depth int) (string, bool) {
+	if literal, ok := parseLiteral(expr); ok {
+		return literal, true
+	}
+
+	if identRe.MatchString(expr) {
+		return r.variable(expr, depth)
+	}
+
+	if match := roleVarCallRe.FindStringSubmatch(expr); match != nil {
+		suffix, args := match[1], match[2]
+		roleName := r.roleName
+		if roleArg := usageRoleArgRe.FindStringSubmatch(args); roleArg != nil {
+			roleName = roleArg[1]
+		}
+		// Instance variables take precedence over role variables, as in the role_var plugin
+		for _, name := range []string{roleName + suffix, roleName + "_role" + suffix} {
+			if value, ok := r.variable(name, depth); ok {
+				return value, true
+			}
+		}
+		if def := roleVarDefaultArgRe.FindStringSubmatch(args); def != nil {
+			return r.primary(def[1], depth)
+		}
+		return "", false
+	}
+
+	return "", false
+}
+
+// variable looks up a variable in the role defaults, then the inventory,
+// and resolves any expression in its value.
+func (r *Resolver) variable(name string, depth int) (string, bool) {
+	value, ok := r.vars[name]
+	if !ok {
+		value, ok = r.inventory[name]
+	}
+	if !ok {
+		return "", false
+	}
+	if !strings.Contains(value, "{{") {
+		return value, true
+	}
+	return r.render(value, depth+1)
+}
+
+// splitFilters splits an expression on top-level "|" characters.
+func splitFilters(expr string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote byte
+	for i := 0; i < len(expr); i++ {
+		ch := expr[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == '|' && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+// parseLiteral parses a quoted string, number or boolean literal.
+func parseLiteral(expr string) (string, bool) {
+	if len(expr) >= 2 && (expr[0] == '\'' || expr[0] == '"') && expr[len(expr)-1] == expr[0] {
+		return expr[1 : len(expr)-1], true
+	}
+	if _, err := strconv.ParseFloat(expr, 64); err == nil {
+		return expr, true
+	}
+	switch expr {
+	case "true", "True":
+		return "true", true
+	case "false", "False":
+		return "false", true
+	}
+	return "", false
+}
+
+// decodeScalar decodes a raw single-line YAML value to its string value.
+func decodeScalar(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.Contains(raw, "\n") {
+		return "", false
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &node); err != nil {
+		return "", false
+	}
+	if len(node.Content) == 0 || node.Content[0].Kind != yaml.ScalarNode {
+		return "", false
+	}
+	return node.Content[0].Value, true
+}
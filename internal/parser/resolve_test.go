@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	roleVars := []Variable{
+		{Name: "plex_name", RawValue: "plex"},
+		{Name: "plex_role_web_subdomain", RawValue: `"{{ lookup('role_var', '_name', role='plex') }}"`},
+		{Name: "plex_role_web_fqdn", RawValue: `"{{ plex_role_web_subdomain }}.{{ user_domain }}"`},
+		{Name: "plex_role_loop_a", RawValue: `"{{ plex_role_loop_b }}"`},
+		{Name: "plex_role_loop_b", RawValue: `"{{ plex_role_loop_a }}"`},
+	}
+	inventory := map[string]string{
+		"user_domain":    "example.com",
+		"plex_role_lite": "{{ plex_name }}-lite",
+		"global_timeout": "30",
+	}
+	resolver := NewResolver("plex", roleVars, inventory)
+
+	tests := []struct {
+		raw      string
+		expected string
+		ok       bool
+	}{
+		{`"{{ plex_name }}"`, "plex", true},
+		{`"{{ lookup('role_var', '_web_subdomain') }}"`, "plex", true},
+		{`"{{ plex_role_web_fqdn }}"`, "plex.example.com", true},
+		{`"{{ lookup('role_var', '_lite') }}"`, "plex-lite", true},
+		{`"{{ lookup('role_var', '_missing', default='fallback') }}"`, "fallback", true},
+		{`"{{ plex_missing | default('none') }}"`, "none", true},
+		{`"{{ plex_missing | d(global_timeout) }}"`, "30", true},
+		{`"{{ plex_name | default('other') }}"`, "plex", true},
+		{`"{{ plex_missing }}"`, "", false},
+		{`"{{ plex_name | upper }}"`, "", false},
+		{`"{{ plex_role_loop_a }}"`, "", false},
+		{`"plain"`, "", false},
+		{"\n  - item", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := resolver.Resolve(tt.raw)
+		if got != tt.expected || ok != tt.ok {
+			t.Errorf("Resolve(%s) = %q, %v, want %q, %v", tt.raw, got, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestLoadInventoryValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "all.yml")
+	content := `---
+plex_name: plex
+timeout: "30"
+nested:
+  key: value
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing inventory: %v", err)
+	}
+
+	values, err := LoadInventoryValues(path)
+	if err != nil {
+		t.Fatalf("LoadInventoryValues returned error: %v", err)
+	}
+	if values["plex_name"] != "plex" || values["timeout"] != "30" {
+		t.Errorf("unexpected values: %v", values)
+	}
+	if _, ok := values["nested"]; ok {
+		t.Error("non-scalar values should be skipped")
+	}
+
+	values, err = LoadInventoryValues(filepath.Join(t.TempDir(), "missing.yml"))
+	if err != nil || len(values) != 0 {
+		t.Errorf("expected no values for missing file, got %v, %v", values, err)
+	}
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/config"
+)
+
+// Type rule issue kinds.
+const (
+	RuleIssueShadowed  = "shadowed"  // the rule can never decide a type
+	RuleIssueAmbiguous = "ambiguous" // the outcome depends only on config order
+)
+
+// TypeRuleIssue describes a type_inference rule that is shadowed or ambiguous.
+type TypeRuleIssue struct {
+	Kind    string
+	Rule    string // e.g. "overrides._port" or "patterns[2]"
+	Message string
+}
+
+// String formats the issue for display.
+func (i TypeRuleIssue) String() string {
+	return fmt.Sprintf("%s (%s): %s", i.Rule, i.Kind, i.Message)
+}
+
+// CheckTypeRules reports type_inference rules that are shadowed by a rule
+// evaluated earlier, or whose precedence over another rule is decided only by
+// their position in the config.
+//
+// Suffix rules are never ambiguous because the longest suffix wins, but an
+// override is shadowed when an exact suffix also matches every name it matches.
+// Regex patterns are only compared with identical regexes.
+func CheckTypeRules(cfg *config.TypeInferenceConfig) []TypeRuleIssue {
+	if cfg == nil {
+		return nil
+	}
+
+	var issues []TypeRuleIssue
+	exact := sortedSuffixRules(cfg.Exact)
+
+	for _, override := range sortedSuffixRules(cfg.Overrides) {
+		for _, e := range exact {
+			if strings.HasSuffix(override.suffix, e.suffix) {
+				issues = append(issues, TypeRuleIssue{
+					Kind: RuleIssueShadowed,
+					Rule: "overrides." + override.suffix,
+					Message: fmt.Sprintf("exact.%s (%s) is checked first and matches every name ending in %q",
+						e.suffix, e.typ, override.suffix),
+				})
+				break
+			}
+		}
+	}
+
+	patterns := sortedPatternRules(cfg.Patterns)
+	for j, later := range patterns {
+		for _, earlier := range patterns[:j] {
+			if issue, ok := comparePatternRules(earlier, later); ok {
+				issues = append(issues, issue)
+				break
+			}
+		}
+	}
+
+	return issues
+}
+
+// comparePatternRules checks a pattern rule against one evaluated before it.
+func comparePatternRules(earlier, later patternRule) (TypeRuleIssue, bool) {
+	rule := fmt.Sprintf("patterns[%d]", later.index)
+
+	if earlier.re != nil || later.re != nil {
+		if earlier.Regex != "" && earlier.Regex == later.Regex {
+			return TypeRuleIssue{
+				Kind:    RuleIssueShadowed,
+				Rule:    rule,
+				Message: fmt.Sprintf("patterns[%d] has the same regex and is checked first", earlier.index),
+			}, true
+		}
+		return TypeRuleIssue{}, false
+	}
+
+	// Every name containing the later substring also contains the earlier one
+	if strings.Contains(later.SuffixContains, earlier.SuffixContains) {
+		return TypeRuleIssue{
+			Kind: RuleIssueShadowed,
+			Rule: rule,
+			Message: fmt.Sprintf("patterns[%d] (%s, %s) is checked first and matches every name it matches",
+				earlier.index, earlier.Describe(), earlier.Type),
+		}, true
+	}
+
+	// The more specific rule only wins because it is listed first
+	if earlier.Priority == later.Priority && earlier.Type != later.Type &&
+		strings.Contains(earlier.SuffixContains, later.SuffixContains) {
+		return TypeRuleIssue{
+			Kind: RuleIssueAmbiguous,
+			Rule: rule,
+			Message: fmt.Sprintf("overlaps patterns[%d] (%s, %s) with equal priority; precedence depends on config order, set priority",
+				earlier.index, earlier.Describe(), earlier.Type),
+		}, true
+	}
+
+	return TypeRuleIssue{}, false
+}
@@ -23,20 +23,23 @@ type Section struct {
 
 // RoleInfo contains all parsed information about a role.
 type RoleInfo struct {
-	Name           string              // Role name (e.g., "plex")
-	RepoType       string              // "saltbox" or "sandbox"
-	Sections       map[string]*Section // Section name -> Section
-	SectionOrder   []string            // Ordered list of section names
-	HasInstances   bool                // Whether role supports multiple instances
-	InstancesVar   string              // Name of instances variable (e.g., "plex_instances")
-	HasDefaultVars bool                // Whether role has _default/_custom vars
-	SSOEnabled     bool                // Whether role has SSO enabled by default
-	HasDNS         bool                // Whether role has a DNS section
-	HasTraefik     bool                // Whether role has a Traefik section
-	HasDocker      bool                // Whether role has a Docker section
-	HasWeb         bool                // Whether role has a Web section
-	HasThemePark   bool                // Whether role has ThemePark variables
-	AllVariables   []Variable          // Flat list of all variables
+	Name           string                    // Role name (e.g., "plex")
+	RepoType       string                    // "saltbox" or "sandbox"
+	Sections       map[string]*Section       // Section name -> Section
+	SectionOrder   []string                  // Ordered list of section names
+	HasInstances   bool                      // Whether role supports multiple instances
+	InstancesVar   string                    // Name of instances variable (e.g., "plex_instances")
+	HasDefaultVars bool                      // Whether role has _default/_custom vars
+	SSOEnabled     bool                      // Whether role has SSO enabled by default
+	HasDNS         bool                      // Whether role has a DNS section
+	HasTraefik     bool                      // Whether role has a Traefik section
+	HasDocker      bool                      // Whether role has a Docker section
+	HasWeb         bool                      // Whether role has a Web section
+	HasThemePark   bool                      // Whether role has ThemePark variables
+	AllVariables   []Variable                // Flat list of all variables
+	Path           string                    // Role directory (parent of defaults/)
+	Dependencies   []Dependency              // Roles required via meta/main.yml or include_role/import_role
+	Usage          map[string]*VariableUsage // Filter and loop usage in tasks, templates and handlers
 }
 
 // ParserState tracks the current parsing context.
@@ -48,3 +51,27 @@ type ParserState struct {
 	InSubsection      bool
 	SkipSection       bool // True when current section should be excluded from docs
 }
+
+// TypeStep is a single rule evaluated while inferring a variable type.
+type TypeStep struct {
+	Rule    string // Rule tier (see the Rule* constants)
+	Matched bool   // Whether this rule decided the type
+	Pattern string // Suffix or pattern involved, if any
+	Detail  string // Human-readable explanation
+}
+
+// TypeDecision is the result of type inference together with its decision trace.
+type TypeDecision struct {
+	Type    string     // Inferred type
+	Rule    string     // Rule tier that decided the type
+	Pattern string     // Suffix or pattern that matched, if any
+	Value   string     // Value or line that triggered the rule, if any
+	Steps   []TypeStep // Rules evaluated, in order
+}
+
+// RoleVarOccurrence is a role_var lookup of a suffix found in the inventory file.
+type RoleVarOccurrence struct {
+	LineNumber int           // Line number in the inventory file
+	Line       string        // Full line text
+	Decision   *TypeDecision // Type decision for this line
+}
@@ -0,0 +1,276 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/saltyorg/docs-automation/internal/types"
+)
+
+// Usage evidence kinds.
+const (
+	UsageFilter = "filter" // piped through a type-revealing filter
+	UsageLoop   = "loop"   // looped over in a task or template
+)
+
+var (
+	// usageDirs are the role directories scanned for variable usage.
+	usageDirs = []string{"tasks", "templates", "handlers"}
+
+	// usageIdentRe matches a variable reference followed by a filter pipe.
+	usageIdentRe = regexp.MustCompile(`\b([A-Za-z_][A-Za-z0-9_]*)\s*\|`)
+
+	// usageRoleVarRe matches a role_var lookup call, capturing the suffix and remaining arguments.
+	usageRoleVarRe = regexp.MustCompile(`lookup\s*\(\s*['"]role_var['"]\s*,\s*['"]([^'"]+)['"]([^)]*)\)`)
+
+	// usageRoleArgRe matches an explicit role= argument of a role_var lookup.
+	usageRoleArgRe = regexp.MustCompile(`role\s*=\s*['"]([A-Za-z0-9_]+)['"]`)
+
+	// usageLoopRe matches Ansible loop keywords over a single Jinja expression.
+	usageLoopRe = regexp.MustCompile(`^\s*(?:-\s+)?(loop|with_items|with_list|with_dict)\s*:\s*["']?\{\{\s*(.+?)\s*\}\}`)
+
+	// usageForRe matches Jinja for loops in templates.
+	usageForRe = regexp.MustCompile(`\{%-?\s*for\s+[\w\s,]+\s+in\s+([A-Za-z_][A-Za-z0-9_]*)(\.items\(\))?`)
+
+	// usageDefaultRe matches a bare variable reference with an optional default filter.
+	usageDefaultRe = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:\s*\|\s*(?:default|d)\s*\(.*\))?$`)
+
+	// usageFilterTypes maps filters that reveal the type of their input.
+	usageFilterTypes = map[string]string{
+		"bool":       types.Bool,
+		"int":        types.Int,
+		"float":      "float",
+		"join":       types.List,
+		"first":      types.List,
+		"last":       types.List,
+		"unique":     types.List,
+		"flatten":    types.List,
+		"union":      types.List,
+		"difference": types.List,
+		"intersect":  types.List,
+		"selectattr": types.List,
+		"rejectattr": types.List,
+		"dict2items": types.Dict,
+		"combine":    types.Dict,
+		"dictsort":   types.Dict,
+	}
+
+	// usagePassthroughFilters are filters skipped when looking for a type-revealing filter.
+	usagePassthroughFilters = map[string]bool{
+		"default": true,
+		"d":       true,
+	}
+
+	// usageLoopTypes maps loop keywords to the type they iterate over.
+	usageLoopTypes = map[string]string{
+		"loop":       types.List,
+		"with_items": types.List,
+		"with_list":  types.List,
+		"with_dict":  types.Dict,
+	}
+)
+
+// UsageEvidence is a single use of a variable that reveals its type.
+type UsageEvidence struct {
+	Type   string // Type implied by the usage
+	Kind   string // UsageFilter or UsageLoop
+	Detail string // e.g. "| bool" or "with_dict"
+	File   string // Path relative to the role directory
+	Line   int    // Line number in File
+}
+
+// Location returns the evidence location as "file:line".
+func (e UsageEvidence) Location() string {
+	return fmt.Sprintf("%s:%d", e.File, e.Line)
+}
+
+// VariableUsage collects usage evidence for a variable across a role.
+type VariableUsage struct {
+	Name     string
+	Evidence []UsageEvidence
+}
+
+// Types returns the distinct types implied by the evidence, sorted.
+func (u *VariableUsage) Types() []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, e := range u.Evidence {
+		if !seen[e.Type] {
+			seen[e.Type] = true
+			result = append(result, e.Type)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Type returns the type implied by the evidence when all evidence agrees.
+func (u *VariableUsage) Type() (string, bool) {
+	typs := u.Types()
+	if len(typs) != 1 {
+		return "", false
+	}
+	return typs[0], true
+}
+
+// ScanRoleUsage walks the role's tasks, templates and handlers and collects
+// filter and loop evidence for every variable referenced there.
+// role_var lookups are attributed to "<role>_role<suffix>", or to the role
+// given with role= when present. A missing role directory yields no usage.
+func ScanRoleUsage(rolePath, roleName string) (map[string]*VariableUsage, error) {
+	usage := make(map[string]*VariableUsage)
+
+	for _, dir := range usageDirs {
+		root := filepath.Join(rolePath, dir)
+		if _, err := os.Stat(root); os.IsNotExist(err) {
+			continue
+		}
+
+		err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(rolePath, path)
+			if err != nil {
+				return err
+			}
+			return scanUsageFile(path, filepath.ToSlash(rel), roleName, usage)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", dir, err)
+		}
+	}
+
+	return usage, nil
+}
+
+// scanUsageFile collects usage evidence from a single file.
+func scanUsageFile(path, rel, roleName string, usage map[string]*VariableUsage) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	add := func(name string, evidence UsageEvidence) {
+		entry, ok := usage[name]
+		if !ok {
+			entry = &VariableUsage{Name: name}
+			usage[name] = entry
+		}
+		evidence.File = rel
+		entry.Evidence = append(entry.Evidence, evidence)
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+
+		// Filters applied to plain variable references
+		for _, match := range usageIdentRe.FindAllStringSubmatchIndex(line, -1) {
+			if isFilterOrAttribute(line, match[2]) {
+				continue
+			}
+			if typ, filter := revealingFilter(line[match[3]:]); typ != "" {
+				add(line[match[2]:match[3]], UsageEvidence{Type: typ, Kind: UsageFilter, Detail: "| " + filter, Line: lineNum})
+			}
+		}
+
+		// Filters applied to role_var lookups
+		for _, match := range usageRoleVarRe.FindAllStringSubmatchIndex(line, -1) {
+			name := roleName + "_role" + line[match[2]:match[3]]
+			if roleArg := usageRoleArgRe.FindStringSubmatch(line[match[4]:match[5]]); roleArg != nil {
+				name = roleArg[1] + "_role" + line[match[2]:match[3]]
+			}
+			if typ, filter := revealingFilter(line[match[1]:]); typ != "" {
+				add(name, UsageEvidence{Type: typ, Kind: UsageFilter, Detail: "| " + filter, Line: lineNum})
+			}
+		}
+
+		// Ansible loops over a bare variable
+		if match := usageLoopRe.FindStringSubmatch(line); match != nil {
+			if ref := usageDefaultRe.FindStringSubmatch(match[2]); ref != nil {
+				add(ref[1], UsageEvidence{Type: usageLoopTypes[match[1]], Kind: UsageLoop, Detail: match[1], Line: lineNum})
+			}
+		}
+
+		// Jinja for loops in templates
+		for _, match := range usageForRe.FindAllStringSubmatch(line, -1) {
+			typ, detail := types.List, "for ... in"
+			if match[2] != "" {
+				typ, detail = types.Dict, "for ... in .items()"
+			}
+			add(match[1], UsageEvidence{Type: typ, Kind: UsageLoop, Detail: detail, Line: lineNum})
+		}
+	}
+
+	return scanner.Err()
+}
+
+// isFilterOrAttribute reports whether the identifier starting at pos is
+// itself a filter name or an attribute access rather than a variable.
+func isFilterOrAttribute(line string, pos int) bool {
+	prev := strings.TrimRight(line[:pos], " \t")
+	return strings.HasSuffix(prev, "|") || strings.HasSuffix(prev, ".")
+}
+
+// revealingFilter parses a filter chain starting at the first "|" of rest and
+// returns the type implied by the first filter that is not a passthrough.
+func revealingFilter(rest string) (string, string) {
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if !strings.HasPrefix(rest, "|") {
+			return "", ""
+		}
+		rest = strings.TrimLeft(rest[1:], " \t")
+
+		end := 0
+		for end < len(rest) && (rest[end] == '_' || isAlnum(rest[end])) {
+			end++
+		}
+		filter := rest[:end]
+		rest = skipCallArgs(rest[end:])
+
+		if usagePassthroughFilters[filter] {
+			continue
+		}
+		typ := usageFilterTypes[filter]
+		if typ == "" {
+			return "", ""
+		}
+		return typ, filter
+	}
+}
+
+// skipCallArgs skips a parenthesized argument list at the start of s, if any.
+func skipCallArgs(s string) string {
+	if !strings.HasPrefix(s, "(") {
+		return s
+	}
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return s[i+1:]
+			}
+		}
+	}
+	return ""
+}
+
+// isAlnum reports whether b is an ASCII letter or digit.
+func isAlnum(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/saltyorg/docs-automation/internal/types"
+)
+
+func writeRoleFile(t *testing.T, rolePath, rel, content string) {
+	t.Helper()
+	path := filepath.Join(rolePath, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("creating dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", rel, err)
+	}
+}
+
+func TestScanRoleUsage(t *testing.T) {
+	rolePath := t.TempDir()
+	writeRoleFile(t, rolePath, "tasks/main.yml", `- name: Setup
+  when: plex_role_enabled | default(false) | bool and lookup('role_var', '_lite') | bool
+  file:
+    path: "{{ item }}"
+  loop: "{{ plex_role_paths }}"
+
+- name: Env
+  with_dict: "{{ plex_role_envs | default({}) }}"
+
+- name: Retries
+  retries: "{{ lookup('role_var', '_retries', role='other') | int }}"
+`)
+	writeRoleFile(t, rolePath, "templates/app.conf.j2", `{% for key, value in plex_role_settings.items() %}
+{{ key }}={{ value | int }}
+{% endfor %}
+{{ plex_role_name | lower | bool }}
+`)
+	writeRoleFile(t, rolePath, "handlers/main.yml", `- name: Restart
+  when: plex_role_enabled | bool
+`)
+
+	usage, err := ScanRoleUsage(rolePath, "plex")
+	if err != nil {
+		t.Fatalf("ScanRoleUsage returned error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected string
+		count    int
+	}{
+		{"plex_role_enabled", types.Bool, 2},
+		{"plex_role_lite", types.Bool, 1},
+		{"plex_role_paths", types.List, 1},
+		{"plex_role_envs", types.Dict, 1},
+		{"other_role_retries", types.Int, 1},
+		{"plex_role_settings", types.Dict, 1},
+	}
+
+	for _, tt := range tests {
+		u, ok := usage[tt.name]
+		if !ok {
+			t.Errorf("expected usage for %s", tt.name)
+			continue
+		}
+		typ, agreed := u.Type()
+		if !agreed || typ != tt.expected || len(u.Evidence) != tt.count {
+			t.Errorf("%s: got %v (%d evidence), want %s (%d)", tt.name, u.Types(), len(u.Evidence), tt.expected, tt.count)
+		}
+	}
+
+	// The first filter after passthrough filters decides; lower does not reveal a type
+	if _, ok := usage["plex_role_name"]; ok {
+		t.Error("plex_role_name should have no usage evidence")
+	}
+	if _, ok := usage["lower"]; ok {
+		t.Error("filter names should not be recorded as variables")
+	}
+
+	if e := usage["plex_role_enabled"].Evidence[1]; e.Location() != "handlers/main.yml:2" {
+		t.Errorf("unexpected evidence location %q", e.Location())
+	}
+}
+
+func TestScanRoleUsage_MissingRole(t *testing.T) {
+	usage, err := ScanRoleUsage(filepath.Join(t.TempDir(), "missing"), "missing")
+	if err != nil || len(usage) != 0 {
+		t.Errorf("expected no usage and no error, got %v, %v", usage, err)
+	}
+}
+
+func TestExplain_UsageTier(t *testing.T) {
+	typeInfer := NewTypeInferrer(nil)
+	typeInfer.SetUsage(map[string]*VariableUsage{
+		"plex_role_lite": {Name: "plex_role_lite", Evidence: []UsageEvidence{
+			{Type: types.Bool, Kind: UsageFilter, Detail: "| bool", File: "tasks/main.yml", Line: 3},
+		}},
+		"plex_role_mixed": {Name: "plex_role_mixed", Evidence: []UsageEvidence{
+			{Type: types.Bool, Kind: UsageFilter, Detail: "| bool"},
+			{Type: types.List, Kind: UsageLoop, Detail: "loop"},
+		}},
+	})
+
+	decision := typeInfer.Explain("plex_role_lite", `"{{ plex_lite }}"`)
+	if decision.Type != types.Bool || decision.Rule != RuleUsage {
+		t.Errorf("expected usage to decide bool, got %s (%s)", decision.Type, decision.Rule)
+	}
+
+	decision = typeInfer.Explain("plex_role_mixed", `"{{ something }}"`)
+	if decision.Type != types.String || decision.Rule != RuleValue {
+		t.Errorf("conflicting usage should fall through to value, got %s (%s)", decision.Type, decision.Rule)
+	}
+}
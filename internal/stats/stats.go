@@ -0,0 +1,276 @@
+// Package stats records documentation coverage statistics over time.
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RepoStats is the documentation coverage of the roles of one repository.
+// Doc counts are over the documented roles.
+type RepoStats struct {
+	Roles                int `json:"roles"`                 // Roles that are not blacklisted
+	Documented           int `json:"documented"`            // Roles with a doc
+	Undocumented         int `json:"undocumented"`          // Roles without a doc
+	WithVariables        int `json:"with_variables"`        // Docs with a managed variables section
+	WithOverview         int `json:"with_overview"`         // Docs with a managed overview section
+	WithSummary          int `json:"with_summary"`          // Docs with project_description.summary
+	WithLinks            int `json:"with_links"`            // Docs with app_links
+	WithCategories       int `json:"with_categories"`       // Docs with project_description.categories
+	Variables            int `json:"variables"`             // Documentable role variables
+	UncommentedVariables int `json:"uncommented_variables"` // Documentable variables without a comment
+}
+
+// Add returns the sum of two repository stats.
+func (r RepoStats) Add(o RepoStats) RepoStats {
+	return RepoStats{
+		Roles:                r.Roles + o.Roles,
+		Documented:           r.Documented + o.Documented,
+		Undocumented:         r.Undocumented + o.Undocumented,
+		WithVariables:        r.WithVariables + o.WithVariables,
+		WithOverview:         r.WithOverview + o.WithOverview,
+		WithSummary:          r.WithSummary + o.WithSummary,
+		WithLinks:            r.WithLinks + o.WithLinks,
+		WithCategories:       r.WithCategories + o.WithCategories,
+		Variables:            r.Variables + o.Variables,
+		UncommentedVariables: r.UncommentedVariables + o.UncommentedVariables,
+	}
+}
+
+// Snapshot is the coverage of all repositories at one point in time, one
+// line of the history file.
+type Snapshot struct {
+	Time  time.Time            `json:"time"`
+	Repos map[string]RepoStats `json:"repos"` // By repository type, e.g. "saltbox"
+}
+
+// RepoTypes returns the repository types of the snapshot in order.
+func (s Snapshot) RepoTypes() []string {
+	types := make([]string, 0, len(s.Repos))
+	for repoType := range s.Repos {
+		types = append(types, repoType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Total returns the stats summed over all repositories.
+func (s Snapshot) Total() RepoStats {
+	var total RepoStats
+	for _, repo := range s.Repos {
+		total = total.Add(repo)
+	}
+	return total
+}
+
+// Percent returns n as a percentage of total, or 0 when total is 0.
+func Percent(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) * 100 / float64(total)
+}
+
+// AppendHistory appends a snapshot to a JSON-lines history file, creating
+// the file if needed.
+func AppendHistory(path string, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("opening history: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing history: %w", err)
+	}
+	return f.Close()
+}
+
+// LoadHistory reads the snapshots of a JSON-lines history file, oldest
+// first. A missing file is an empty history.
+func LoadHistory(path string) ([]Snapshot, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening history: %w", err)
+	}
+	defer f.Close()
+
+	var history []Snapshot
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var snapshot Snapshot
+		if err := json.Unmarshal([]byte(text), &snapshot); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		history = append(history, snapshot)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading history: %w", err)
+	}
+	return history, nil
+}
+
+// sparkBlocks are the bars of a sparkline, lowest first.
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of block characters scaled between
+// their minimum and maximum. Equal values are drawn at mid height.
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		level := len(sparkBlocks) / 2
+		if hi > lo {
+			level = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		sb.WriteRune(sparkBlocks[level])
+	}
+	return sb.String()
+}
+
+// trendMetric is a metric shown in the trend of the report.
+type trendMetric struct {
+	name  string
+	value func(RepoStats) float64
+	unit  string // "%" for percentages, "" for counts
+}
+
+var trendMetrics = []trendMetric{
+	{"Documented", func(r RepoStats) float64 { return Percent(r.Documented, r.Roles) }, "%"},
+	{"Variables Sections", func(r RepoStats) float64 { return Percent(r.WithVariables, r.Documented) }, "%"},
+	{"Overview Sections", func(r RepoStats) float64 { return Percent(r.WithOverview, r.Documented) }, "%"},
+	{"Summary", func(r RepoStats) float64 { return Percent(r.WithSummary, r.Documented) }, "%"},
+	{"Links", func(r RepoStats) float64 { return Percent(r.WithLinks, r.Documented) }, "%"},
+	{"Categories", func(r RepoStats) float64 { return Percent(r.WithCategories, r.Documented) }, "%"},
+	{"Uncommented Variables", func(r RepoStats) float64 { return float64(r.UncommentedVariables) }, ""},
+}
+
+// formatMetric formats a metric value with its unit.
+func formatMetric(value float64, unit string) string {
+	if unit == "%" {
+		return fmt.Sprintf("%.1f%%", value)
+	}
+	return fmt.Sprintf("%.0f", value)
+}
+
+// Markdown renders the latest snapshot of a history as markdown: a table
+// of the coverage per repository, and the trend of the totals over the
+// last runs runs. The trend is left out with fewer than two runs recorded
+// or runs < 1.
+func Markdown(history []Snapshot, runs int) string {
+	var sb strings.Builder
+	sb.WriteString("## 📊 Documentation Coverage\n\n")
+	if len(history) == 0 {
+		sb.WriteString("No statistics recorded yet.\n")
+		return sb.String()
+	}
+	current := history[len(history)-1]
+	repoTypes := current.RepoTypes()
+
+	// Coverage per repository
+	sb.WriteString("| Metric |")
+	for _, repoType := range repoTypes {
+		sb.WriteString(fmt.Sprintf(" %s |", repoType))
+	}
+	sb.WriteString(" Total |\n|--------|")
+	for range repoTypes {
+		sb.WriteString("------|")
+	}
+	sb.WriteString("-------|\n")
+
+	rows := []struct {
+		name string
+		cell func(RepoStats) string
+	}{
+		{"Roles", func(r RepoStats) string { return fmt.Sprint(r.Roles) }},
+		{"Documented", func(r RepoStats) string { return ratio(r.Documented, r.Roles) }},
+		{"Undocumented", func(r RepoStats) string { return fmt.Sprint(r.Undocumented) }},
+		{"Variables Sections", func(r RepoStats) string { return ratio(r.WithVariables, r.Documented) }},
+		{"Overview Sections", func(r RepoStats) string { return ratio(r.WithOverview, r.Documented) }},
+		{"Summary", func(r RepoStats) string { return ratio(r.WithSummary, r.Documented) }},
+		{"Links", func(r RepoStats) string { return ratio(r.WithLinks, r.Documented) }},
+		{"Categories", func(r RepoStats) string { return ratio(r.WithCategories, r.Documented) }},
+		{"Uncommented Variables", func(r RepoStats) string {
+			return fmt.Sprintf("%d of %d", r.UncommentedVariables, r.Variables)
+		}},
+	}
+	for _, row := range rows {
+		sb.WriteString(fmt.Sprintf("| %s |", row.name))
+		for _, repoType := range repoTypes {
+			sb.WriteString(fmt.Sprintf(" %s |", row.cell(current.Repos[repoType])))
+		}
+		sb.WriteString(fmt.Sprintf(" %s |\n", row.cell(current.Total())))
+	}
+	sb.WriteString("\n")
+
+	if len(history) < 2 || runs < 1 {
+		return sb.String()
+	}
+
+	recent := history[max(len(history)-runs, 0):]
+	sb.WriteString(fmt.Sprintf("### Trend (last %d runs)\n\n", len(recent)))
+
+	// Sparkline per metric, from the first to the latest run shown
+	sb.WriteString("| Metric | Trend | First | Latest |\n")
+	sb.WriteString("|--------|-------|-------|--------|\n")
+	for _, metric := range trendMetrics {
+		values := make([]float64, len(recent))
+		for i, snapshot := range recent {
+			values[i] = metric.value(snapshot.Total())
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", metric.name, Sparkline(values),
+			formatMetric(values[0], metric.unit), formatMetric(values[len(values)-1], metric.unit)))
+	}
+	sb.WriteString("\n")
+
+	// Totals of each run, latest first
+	sb.WriteString("<details>\n<summary>Runs</summary>\n\n| Date |")
+	for _, metric := range trendMetrics {
+		sb.WriteString(fmt.Sprintf(" %s |", metric.name))
+	}
+	sb.WriteString("\n|------|")
+	for range trendMetrics {
+		sb.WriteString("------|")
+	}
+	sb.WriteString("\n")
+	for i := len(recent) - 1; i >= 0; i-- {
+		total := recent[i].Total()
+		sb.WriteString(fmt.Sprintf("| %s |", recent[i].Time.UTC().Format("2006-01-02 15:04")))
+		for _, metric := range trendMetrics {
+			sb.WriteString(fmt.Sprintf(" %s |", formatMetric(metric.value(total), metric.unit)))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n</details>\n")
+
+	return sb.String()
+}
+
+// ratio formats n of total with its percentage.
+func ratio(n, total int) string {
+	return fmt.Sprintf("%d (%.1f%%)", n, Percent(n, total))
+}
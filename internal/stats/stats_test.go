@@ -0,0 +1,116 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	history, err := LoadHistory(path)
+	if err != nil || history != nil {
+		t.Fatalf("LoadHistory of missing file = %v, %v; want empty", history, err)
+	}
+
+	first := Snapshot{
+		Time:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Repos: map[string]RepoStats{"saltbox": {Roles: 10, Documented: 8, Undocumented: 2}},
+	}
+	second := Snapshot{
+		Time:  time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC),
+		Repos: map[string]RepoStats{"saltbox": {Roles: 10, Documented: 9, Undocumented: 1}},
+	}
+	for _, snapshot := range []Snapshot{first, second} {
+		if err := AppendHistory(path, snapshot); err != nil {
+			t.Fatalf("AppendHistory: %v", err)
+		}
+	}
+
+	history, err = LoadHistory(path)
+	if err != nil {
+		t.Fatalf("LoadHistory: %v", err)
+	}
+	if !reflect.DeepEqual(history, []Snapshot{first, second}) {
+		t.Errorf("LoadHistory = %+v, want both snapshots in order", history)
+	}
+
+	if err := os.WriteFile(path, []byte("{}\nnot json\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHistory(path); err == nil || !strings.Contains(err.Error(), ":2:") {
+		t.Errorf("LoadHistory of malformed line = %v, want error naming line 2", err)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values   []float64
+		expected string
+	}{
+		{nil, ""},
+		{[]float64{50, 50}, "▅▅"},
+		{[]float64{0, 50, 100}, "▁▄█"},
+		{[]float64{80, 75, 90}, "▃▁█"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.expected {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.expected)
+		}
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	snapshot := func(day, documented int) Snapshot {
+		return Snapshot{
+			Time: time.Date(2026, 10, day, 12, 0, 0, 0, time.UTC),
+			Repos: map[string]RepoStats{
+				"saltbox": {Roles: 10, Documented: documented, Undocumented: 10 - documented, WithVariables: documented, Variables: 40, UncommentedVariables: 12},
+				"sandbox": {Roles: 10, Documented: 5, Undocumented: 5, WithOverview: 1, Variables: 20, UncommentedVariables: 3},
+			},
+		}
+	}
+
+	single := Markdown([]Snapshot{snapshot(1, 6)}, 10)
+	for _, want := range []string{
+		"| Metric | saltbox | sandbox | Total |\n",
+		"| Documented | 6 (60.0%) | 5 (50.0%) | 11 (55.0%) |\n",
+		"| Overview Sections | 0 (0.0%) | 1 (20.0%) | 1 (9.1%) |\n",
+		"| Uncommented Variables | 12 of 40 | 3 of 20 | 15 of 60 |\n",
+	} {
+		if !strings.Contains(single, want) {
+			t.Errorf("Markdown() does not contain %q:\n%s", want, single)
+		}
+	}
+	if strings.Contains(single, "### Trend") {
+		t.Error("Markdown() of a single run shows a trend")
+	}
+
+	history := []Snapshot{snapshot(1, 6), snapshot(2, 8), snapshot(3, 10)}
+	trend := Markdown(history, 2)
+	for _, want := range []string{
+		"### Trend (last 2 runs)\n",
+		"| Documented | ▁█ | 65.0% | 75.0% |\n",
+		"| 2026-10-03 12:00 | 75.0% |",
+	} {
+		if !strings.Contains(trend, want) {
+			t.Errorf("Markdown() does not contain %q:\n%s", want, trend)
+		}
+	}
+	if strings.Contains(trend, "2026-10-01") {
+		t.Error("Markdown() shows runs beyond the limit")
+	}
+	if strings.Index(trend, "2026-10-03") > strings.Index(trend, "2026-10-02") {
+		t.Error("Markdown() does not list the latest run first")
+	}
+
+	for _, runs := range []int{0, -1} {
+		if strings.Contains(Markdown(history, runs), "### Trend") {
+			t.Errorf("Markdown(runs=%d) shows a trend", runs)
+		}
+	}
+}
@@ -15,6 +15,11 @@ type RoleData struct {
 	RoleName string
 	RepoType string // "saltbox" or "sandbox"
 
+	// Playbook tags (from saltbox.yml / sandbox.yml)
+	Tags      []string // All tags the role is included with
+	RoleTag   string   // Tag used in the install command (e.g., "sonarr")
+	TagPrefix string   // "" for saltbox, "sandbox-" for sandbox
+
 	// Multi-instance support
 	HasInstances bool
 	InstancesVar string
@@ -33,6 +38,9 @@ type RoleData struct {
 	// Docker+ variables (from resources/tasks/docker/*.yml)
 	DockerInfo *DockerInfo
 
+	// Roles this role requires (from meta/main.yml and include_role/import_role)
+	Dependencies []*DependencyData
+
 	// Example override (first variable for example display)
 	ExampleVar   string
 	ExampleValue string
@@ -80,6 +88,16 @@ type VariableData struct {
 	IsMultiline  bool
 	ValueLines   []string
 	InstanceName string // Instance-level variable name
+
+	// ResolvedValue is the statically resolved value of a Jinja default,
+	// e.g. "plex" for "{{ plex_name }}". Empty when it could not be resolved.
+	ResolvedValue string
+}
+
+// DependencyData represents a required role for template rendering.
+type DependencyData struct {
+	Name   string // Role name (e.g., "redis")
+	Source string // "meta", "include_role" or "import_role"
 }
 
 // DockerInfo contains Docker+ variable information.
@@ -98,8 +116,11 @@ type GlobalOverrideVar struct {
 	Example     string // Example from config (optional)
 }
 
-// BuildRoleData creates RoleData from parsed role information.
-func BuildRoleData(role *parser.RoleInfo, cfg *config.Config, fmConfig *docs.SaltboxAutomationConfig) *RoleData {
+// BuildRoleData creates RoleData from parsed role information. playbookTags
+// are the install tags of the playbook of the role's repository, and
+// inventoryValues the values Jinja defaults are resolved against (see
+// parser.LoadInventoryValues).
+func BuildRoleData(role *parser.RoleInfo, cfg *config.Config, fmConfig *docs.SaltboxAutomationConfig, playbookTags parser.PlaybookTags, inventoryValues map[string]string) *RoleData {
 	data := &RoleData{
 		RoleName:       role.Name,
 		RepoType:       role.RepoType,
@@ -114,14 +135,32 @@ func BuildRoleData(role *parser.RoleInfo, cfg *config.Config, fmConfig *docs.Sal
 		GlobalConfig:   cfg,
 	}
 
+	// Resolve install tags from the playbook
+	data.Tags = playbookTags.Tags(role.Name)
+	data.RoleTag = playbookTags.PrimaryTag(role.Name)
+	if role.RepoType == "sandbox" {
+		data.TagPrefix = parser.SandboxTagPrefix
+	}
+
+	for _, dep := range role.Dependencies {
+		data.Dependencies = append(data.Dependencies, &DependencyData{Name: dep.Name, Source: dep.Source})
+	}
+
 	// Build type inferrer
 	var typeInfer *parser.TypeInferrer
 	if cfg != nil {
 		typeInfer = parser.NewTypeInferrer(&cfg.TypeInference)
+		typeInfer.SetUsage(role.Usage)
 	} else {
 		typeInfer = parser.NewTypeInferrer(nil)
 	}
 
+	// Build resolver for Jinja defaults
+	var resolver *parser.Resolver
+	if cfg != nil {
+		resolver = parser.NewResolver(role.Name, role.AllVariables, inventoryValues)
+	}
+
 	// Build set of base variables to hide (those with both _default and _custom variants)
 	hideBase := parser.BuildHideBaseSet(role.AllVariables)
 
@@ -150,7 +189,7 @@ func BuildRoleData(role *parser.RoleInfo, cfg *config.Config, fmConfig *docs.Sal
 				if hideBase[v.Name] {
 					continue
 				}
-				varData := buildVariableData(&v, role.Name, data.InstanceName, typeInfer, fmConfig)
+				varData := buildVariableData(&v, role.Name, data.InstanceName, typeInfer, resolver, fmConfig)
 				sectionData.Variables = append(sectionData.Variables, varData)
 			}
 
@@ -184,7 +223,7 @@ func BuildRoleData(role *parser.RoleInfo, cfg *config.Config, fmConfig *docs.Sal
 				if hideBase[v.Name] {
 					continue
 				}
-				varData := buildVariableData(&v, role.Name, data.InstanceName, typeInfer, fmConfig)
+				varData := buildVariableData(&v, role.Name, data.InstanceName, typeInfer, resolver, fmConfig)
 				sectionData.Variables = append(sectionData.Variables, varData)
 			}
 
@@ -196,7 +235,7 @@ func BuildRoleData(role *parser.RoleInfo, cfg *config.Config, fmConfig *docs.Sal
 					if hideBase[v.Name] {
 						continue
 					}
-					varData := buildVariableData(&v, role.Name, data.InstanceName, typeInfer, fmConfig)
+					varData := buildVariableData(&v, role.Name, data.InstanceName, typeInfer, resolver, fmConfig)
 					sectionData.Subsections[subName] = append(sectionData.Subsections[subName], varData)
 				}
 			}
@@ -343,7 +382,7 @@ func buildDockerInfo(cfg *config.Config, roleName string, roleDockerVars []strin
 }
 
 // buildVariableData creates VariableData from a parsed Variable.
-func buildVariableData(v *parser.Variable, roleName, instanceName string, typeInfer *parser.TypeInferrer, fmConfig *docs.SaltboxAutomationConfig) *VariableData {
+func buildVariableData(v *parser.Variable, roleName, instanceName string, typeInfer *parser.TypeInferrer, resolver *parser.Resolver, fmConfig *docs.SaltboxAutomationConfig) *VariableData {
 	// Check for example override
 	rawValue := v.RawValue
 	if fmConfig != nil {
@@ -358,6 +397,12 @@ func buildVariableData(v *parser.Variable, roleName, instanceName string, typeIn
 	// Generate instance name
 	instName := parser.GenerateInstanceName(v.Name, roleName, instanceName)
 
+	// Resolve Jinja defaults to their effective value
+	var resolved string
+	if resolver != nil {
+		resolved, _ = resolver.Resolve(rawValue)
+	}
+
 	// Split comment into lines
 	var commentLines []string
 	if v.Comment != "" {
@@ -365,14 +410,15 @@ func buildVariableData(v *parser.Variable, roleName, instanceName string, typeIn
 	}
 
 	return &VariableData{
-		Name:         v.Name,
-		RawValue:     rawValue,
-		Type:         typ,
-		Comment:      v.Comment,
-		CommentLines: commentLines,
-		IsMultiline:  v.IsMultiline,
-		ValueLines:   v.ValueLines,
-		InstanceName: instName,
+		Name:          v.Name,
+		RawValue:      rawValue,
+		Type:          typ,
+		Comment:       v.Comment,
+		CommentLines:  commentLines,
+		IsMultiline:   v.IsMultiline,
+		ValueLines:    v.ValueLines,
+		InstanceName:  instName,
+		ResolvedValue: resolved,
 	}
 }
 
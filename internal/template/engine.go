@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"text/template"
 )
 
@@ -40,6 +43,93 @@ func (e *Engine) LoadString(name, content string) error {
 	return nil
 }
 
+// LoadPartials parses shared partials matching pattern into a loaded template.
+// Partials use {{define}} to provide named templates that the layout and its
+// variants can call. A pattern with no matches is not an error.
+func (e *Engine) LoadPartials(name, pattern string) error {
+	tmpl, ok := e.templates[name]
+	if !ok {
+		return fmt.Errorf("template %q not found", name)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("matching partials: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	if _, err := tmpl.ParseGlob(pattern); err != nil {
+		return fmt.Errorf("parsing partials: %w", err)
+	}
+	return nil
+}
+
+// LoadVariants loads per-document variants of a base template from files matching pattern.
+// Each variant is parsed on top of a clone of the base, so it only needs to
+// redefine the {{block}}s it wants to change. Variants are registered as
+// "<base>/<variant>", where variant is the file name without its extensions.
+func (e *Engine) LoadVariants(base, pattern string) error {
+	baseTmpl, ok := e.templates[base]
+	if !ok {
+		return fmt.Errorf("template %q not found", base)
+	}
+
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("matching variants: %w", err)
+	}
+
+	for _, path := range matches {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading variant %s: %w", path, err)
+		}
+
+		tmpl, err := baseTmpl.Clone()
+		if err != nil {
+			return fmt.Errorf("cloning template %q: %w", base, err)
+		}
+
+		if _, err := tmpl.Parse(string(content)); err != nil {
+			return fmt.Errorf("parsing variant %s: %w", path, err)
+		}
+
+		e.templates[base+"/"+VariantName(path)] = tmpl
+	}
+
+	return nil
+}
+
+// Has returns true if a template with the given name has been loaded.
+func (e *Engine) Has(name string) bool {
+	_, ok := e.templates[name]
+	return ok
+}
+
+// Lookup returns the loaded template with the given name, or nil.
+func (e *Engine) Lookup(name string) *template.Template {
+	return e.templates[name]
+}
+
+// Names returns the names of all loaded templates in sorted order.
+func (e *Engine) Names() []string {
+	names := make([]string, 0, len(e.templates))
+	for name := range e.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// VariantName returns the variant name for a template file path.
+// For example "templates/inventory/arr.md.tmpl" -> "arr".
+func VariantName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".tmpl")
+	return strings.TrimSuffix(name, ".md")
+}
+
 // Render renders a template with the given data.
 func (e *Engine) Render(name string, data any) (string, error) {
 	tmpl, ok := e.templates[name]
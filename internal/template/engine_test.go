@@ -0,0 +1,90 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadVariants_OverridesBlocks(t *testing.T) {
+	tmpDir := t.TempDir()
+	variantsDir := filepath.Join(tmpDir, "inventory")
+	partialsDir := filepath.Join(tmpDir, "partials")
+	for _, dir := range []string{variantsDir, partialsDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("creating %s: %v", dir, err)
+		}
+	}
+
+	base := `{{block "header" .}}# {{.RoleName}}{{end}}
+{{template "footer" .}}`
+	partial := `{{define "footer"}}-- {{.RepoType}}{{end}}`
+	variant := `{{define "header"}}## {{.RoleName}} (multi-instance){{end}}`
+
+	if err := os.WriteFile(filepath.Join(partialsDir, "footer.tmpl"), []byte(partial), 0o644); err != nil {
+		t.Fatalf("writing partial: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(variantsDir, "arr.md.tmpl"), []byte(variant), 0o644); err != nil {
+		t.Fatalf("writing variant: %v", err)
+	}
+
+	engine := New()
+	if err := engine.LoadString("inventory", base); err != nil {
+		t.Fatalf("LoadString failed: %v", err)
+	}
+	if err := engine.LoadPartials("inventory", filepath.Join(partialsDir, "*.tmpl")); err != nil {
+		t.Fatalf("LoadPartials failed: %v", err)
+	}
+	if err := engine.LoadVariants("inventory", filepath.Join(variantsDir, "*.md.tmpl")); err != nil {
+		t.Fatalf("LoadVariants failed: %v", err)
+	}
+
+	if !engine.Has("inventory/arr") {
+		t.Fatal("expected variant inventory/arr to be registered")
+	}
+
+	data := &RoleData{RoleName: "sonarr", RepoType: "saltbox"}
+
+	got, err := engine.Render("inventory", data)
+	if err != nil {
+		t.Fatalf("Render base failed: %v", err)
+	}
+	if want := "# sonarr\n-- saltbox"; got != want {
+		t.Errorf("base render = %q, want %q", got, want)
+	}
+
+	got, err = engine.Render("inventory/arr", data)
+	if err != nil {
+		t.Fatalf("Render variant failed: %v", err)
+	}
+	if want := "## sonarr (multi-instance)\n-- saltbox"; got != want {
+		t.Errorf("variant render = %q, want %q", got, want)
+	}
+}
+
+func TestLoadPartials_NoMatches(t *testing.T) {
+	engine := New()
+	if err := engine.LoadString("inventory", "body"); err != nil {
+		t.Fatalf("LoadString failed: %v", err)
+	}
+	if err := engine.LoadPartials("inventory", filepath.Join(t.TempDir(), "*.tmpl")); err != nil {
+		t.Fatalf("expected no error for empty partials dir, got %v", err)
+	}
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"templates/inventory/arr.md.tmpl", "arr"},
+		{"templates/inventory/database.tmpl", "database"},
+		{"plain", "plain"},
+	}
+
+	for _, tt := range tests {
+		if got := VariantName(tt.path); got != tt.expected {
+			t.Errorf("VariantName(%q) = %q, want %q", tt.path, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package template
+
+import (
+	"github.com/saltyorg/docs-automation/internal/config"
+	"github.com/saltyorg/docs-automation/internal/docs"
+)
+
+// SampleRoleData returns synthetic RoleData used to smoke render inventory templates.
+// Every optional field is populated so that all template branches can execute.
+func SampleRoleData() *RoleData {
+	enabled := true
+	defaultValue := "false"
+
+	simple := &VariableData{
+		Name:         "sampleapp_name",
+		RawValue:     `"sampleapp"`,
+		Type:         "string",
+		Comment:      "Name of the container",
+		CommentLines: []string{"Name of the container"},
+		ValueLines:   []string{`"sampleapp"`},
+		InstanceName: "sampleapp2_name",
+	}
+	multiline := &VariableData{
+		Name:         "sampleapp_role_docker_envs_default",
+		RawValue:     "\n  TZ: \"{{ tz }}\"\n  PUID: \"{{ uid }}\"",
+		Type:         "dict",
+		Comment:      "Environment variables\nMerged with custom envs",
+		CommentLines: []string{"Environment variables", "Merged with custom envs"},
+		IsMultiline:  true,
+		ValueLines:   []string{"", `  TZ: "{{ tz }}"`, `  PUID: "{{ uid }}"`},
+		InstanceName: "sampleapp2_docker_envs_default",
+	}
+	resolved := &VariableData{
+		Name:          "sampleapp_role_web_subdomain",
+		RawValue:      `"{{ sampleapp_name }}"`,
+		Type:          "string",
+		ValueLines:    []string{`"{{ sampleapp_name }}"`},
+		InstanceName:  "sampleapp2_web_subdomain",
+		ResolvedValue: "sampleapp",
+	}
+	flag := &VariableData{
+		Name:         "sampleapp_role_web_insecure",
+		RawValue:     "false",
+		Type:         "bool",
+		ValueLines:   []string{"false"},
+		InstanceName: "sampleapp2_web_insecure",
+	}
+
+	return &RoleData{
+		RoleName:     "sampleapp",
+		RepoType:     "saltbox",
+		HasInstances: true,
+		InstancesVar: "sampleapp_instances",
+		InstanceName: "sampleapp2",
+		Tags:         []string{"sampleapp", "sampleapps"},
+		RoleTag:      "sampleapp",
+		Dependencies: []*DependencyData{
+			{Name: "docker", Source: "meta"},
+			{Name: "redis", Source: "include_role"},
+		},
+		Sections: map[string]*SectionData{
+			"Basics": {
+				Name:        "Basics",
+				Variables:   []*VariableData{simple, resolved},
+				Subsections: map[string][]*VariableData{},
+			},
+			"Docker": {
+				Name:      "Docker",
+				Variables: []*VariableData{multiline},
+				Subsections: map[string][]*VariableData{
+					"Web": {flag},
+				},
+				SubsectionOrder: []string{"Web"},
+			},
+		},
+		SectionOrder:   []string{"Basics", "Docker"},
+		HasDefaultVars: true,
+		RoleVarLookups: map[string]*GlobalOverrideVar{
+			"_web_insecure": {
+				Suffix:      "_web_insecure",
+				Type:        "bool",
+				Description: "Skip TLS verification",
+				Default:     defaultValue,
+				HasDefault:  true,
+				Example:     "sampleapp_role_web_insecure: true",
+			},
+		},
+		DockerInfo: &DockerInfo{
+			Categories: map[string][]string{
+				"Resource Limits": {"memory"},
+				"Networking":      {"dns_servers"},
+			},
+			CategoryOrder: []string{"Resource Limits", "Networking"},
+		},
+		ExampleVar:   "sampleapp_name",
+		ExampleValue: `"custom_value"`,
+		Config: &docs.SaltboxAutomationConfig{
+			Sections: docs.SectionsConfig{Inventory: &enabled, Overview: &enabled},
+			Inventory: docs.InventoryConfig{
+				ExampleOverrides: map[string]string{"sampleapp_name": `"custom"`},
+			},
+			AppLinks: []docs.AppLink{{Name: "Manual", URL: "https://example.com/docs", Type: "manual"}},
+			ProjectDescription: &docs.ProjectDescription{
+				Name:       "Sample App",
+				Summary:    "A sample application",
+				Link:       "https://example.com",
+				Categories: []string{"Media Apps > Sample"},
+			},
+		},
+		GlobalConfig: &config.Config{},
+	}
+}
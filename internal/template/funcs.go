@@ -4,15 +4,17 @@ import (
 	"strings"
 	"text/template"
 
+	"github.com/saltyorg/docs-automation/internal/funcs"
 	"github.com/saltyorg/docs-automation/internal/parser"
 	"github.com/saltyorg/docs-automation/internal/types"
 )
 
-// FuncMap returns the template function map.
+// FuncMap returns the inventory template function map.
+// It extends the shared function library (see package funcs) with
+// helpers that operate on role variables.
 func FuncMap() template.FuncMap {
-	return template.FuncMap{
+	return funcs.Merge(template.FuncMap{
 		// Formatting functions
-		"indent":            indent,
 		"formatTypeComment": formatTypeComment,
 		"typeKeyword":       typeKeyword,
 
@@ -29,19 +31,7 @@ func FuncMap() template.FuncMap {
 		"replaceRole":           replaceRole,
 		"replacePlural":         replacePlural,
 		"formatOverrideDefault": formatOverrideDefault,
-	}
-}
-
-// indent adds n spaces of indentation to each line.
-func indent(n int, s string) string {
-	prefix := strings.Repeat(" ", n)
-	lines := strings.Split(s, "\n")
-	for i, line := range lines {
-		if line != "" {
-			lines[i] = prefix + line
-		}
-	}
-	return strings.Join(lines, "\n")
+	})
 }
 
 // getValueLines returns the continuation lines (all lines after the first) for template iteration.
@@ -0,0 +1,399 @@
+package template
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"text/template"
+	"text/template/parse"
+)
+
+// execErrorRe splits a text/template execution error into location and message.
+// Example: "template: inventory:12:7: executing ..." -> "inventory:12:7", "executing ..."
+var execErrorRe = regexp.MustCompile(`^template: ([^:]+:\d+(?::\d+)?): (.*)$`)
+
+// LintIssue describes a problem found while linting a template.
+type LintIssue struct {
+	Location string // "name:line:col" within the template
+	Message  string
+}
+
+// String formats the issue as "location: message".
+func (i LintIssue) String() string {
+	return i.Location + ": " + i.Message
+}
+
+// Lint checks a parsed template against the data it is rendered with.
+// It walks the parse tree and verifies every field reference against the
+// type of data (following range, with, variables and {{template}} calls),
+// then performs a smoke render with data as fixture if no static problems
+// were found. The funcs map is used to infer the result type of function calls.
+func Lint(tmpl *template.Template, funcs template.FuncMap, data any) []LintIssue {
+	l := &linter{
+		tmpl:    tmpl,
+		funcs:   funcs,
+		checked: make(map[string]bool),
+		seen:    make(map[string]bool),
+	}
+
+	l.checkTemplate(tmpl.Name(), reflect.TypeOf(data))
+
+	if len(l.issues) > 0 {
+		return l.issues
+	}
+
+	if err := tmpl.Execute(io.Discard, data); err != nil {
+		issue := LintIssue{Location: tmpl.Name(), Message: err.Error()}
+		if matches := execErrorRe.FindStringSubmatch(err.Error()); matches != nil {
+			issue.Location = matches[1]
+			issue.Message = "smoke render: " + matches[2]
+		}
+		l.add(issue)
+	}
+
+	return l.issues
+}
+
+// linter holds state for a single Lint run.
+type linter struct {
+	tmpl    *template.Template
+	funcs   template.FuncMap
+	checked map[string]bool // template name + dot type already walked
+	seen    map[string]bool // issues already reported
+	issues  []LintIssue
+}
+
+// variable is a template variable in scope with its static type (nil if unknown).
+type variable struct {
+	name string
+	typ  reflect.Type
+}
+
+// add records an issue unless it was already reported.
+func (l *linter) add(issue LintIssue) {
+	key := issue.String()
+	if l.seen[key] {
+		return
+	}
+	l.seen[key] = true
+	l.issues = append(l.issues, issue)
+}
+
+// report records an issue for a node in the given tree.
+func (l *linter) report(tree *parse.Tree, node parse.Node, format string, args ...any) {
+	location, _ := tree.ErrorContext(node)
+	l.add(LintIssue{Location: location, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkTemplate walks the named template with dot of the given type.
+func (l *linter) checkTemplate(name string, dot reflect.Type) {
+	key := name + "|" + typeName(dot)
+	if l.checked[key] {
+		return
+	}
+	l.checked[key] = true
+
+	t := l.tmpl.Lookup(name)
+	if t == nil || t.Tree == nil || t.Root == nil {
+		return
+	}
+
+	vars := []variable{{name: "$", typ: dot}}
+	l.walk(t.Tree, t.Root, dot, &vars)
+}
+
+// walk checks a node and its children.
+func (l *linter) walk(tree *parse.Tree, node parse.Node, dot reflect.Type, vars *[]variable) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		mark := len(*vars)
+		for _, child := range n.Nodes {
+			l.walk(tree, child, dot, vars)
+		}
+		*vars = (*vars)[:mark]
+
+	case *parse.ActionNode:
+		typ := l.pipeType(tree, n.Pipe, dot, *vars)
+		l.declare(n.Pipe, typ, vars)
+
+	case *parse.IfNode:
+		mark := len(*vars)
+		typ := l.pipeType(tree, n.Pipe, dot, *vars)
+		l.declare(n.Pipe, typ, vars)
+		l.walk(tree, n.List, dot, vars)
+		l.walk(tree, n.ElseList, dot, vars)
+		*vars = (*vars)[:mark]
+
+	case *parse.WithNode:
+		mark := len(*vars)
+		typ := l.pipeType(tree, n.Pipe, dot, *vars)
+		l.declare(n.Pipe, typ, vars)
+		l.walk(tree, n.List, typ, vars)
+		l.walk(tree, n.ElseList, dot, vars)
+		*vars = (*vars)[:mark]
+
+	case *parse.RangeNode:
+		mark := len(*vars)
+		key, elem := rangeTypes(l.pipeType(tree, n.Pipe, dot, *vars))
+		switch len(n.Pipe.Decl) {
+		case 1:
+			*vars = append(*vars, variable{name: n.Pipe.Decl[0].Ident[0], typ: elem})
+		case 2:
+			*vars = append(*vars,
+				variable{name: n.Pipe.Decl[0].Ident[0], typ: key},
+				variable{name: n.Pipe.Decl[1].Ident[0], typ: elem})
+		}
+		l.walk(tree, n.List, elem, vars)
+		l.walk(tree, n.ElseList, dot, vars)
+		*vars = (*vars)[:mark]
+
+	case *parse.TemplateNode:
+		var typ reflect.Type
+		if n.Pipe != nil {
+			typ = l.pipeType(tree, n.Pipe, dot, *vars)
+		}
+		if l.tmpl.Lookup(n.Name) == nil {
+			l.report(tree, n, "no such template %q", n.Name)
+			return
+		}
+		l.checkTemplate(n.Name, typ)
+	}
+}
+
+// declare assigns the pipeline result type to the variables it declares.
+func (l *linter) declare(pipe *parse.PipeNode, typ reflect.Type, vars *[]variable) {
+	if pipe == nil {
+		return
+	}
+	for _, decl := range pipe.Decl {
+		name := decl.Ident[0]
+		if pipe.IsAssign {
+			for i := len(*vars) - 1; i >= 0; i-- {
+				if (*vars)[i].name == name {
+					(*vars)[i].typ = typ
+					break
+				}
+			}
+			continue
+		}
+		*vars = append(*vars, variable{name: name, typ: typ})
+	}
+}
+
+// pipeType checks a pipeline and returns the static type of its result.
+func (l *linter) pipeType(tree *parse.Tree, pipe *parse.PipeNode, dot reflect.Type, vars []variable) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var typ reflect.Type
+	for _, cmd := range pipe.Cmds {
+		typ = l.cmdType(tree, cmd, dot, vars)
+	}
+	return typ
+}
+
+// cmdType checks a single pipeline command and returns its result type.
+func (l *linter) cmdType(tree *parse.Tree, cmd *parse.CommandNode, dot reflect.Type, vars []variable) reflect.Type {
+	if len(cmd.Args) == 0 {
+		return nil
+	}
+
+	// Check all arguments after the first for field references.
+	var argTypes []reflect.Type
+	for _, arg := range cmd.Args[1:] {
+		argTypes = append(argTypes, l.argType(tree, arg, dot, vars))
+	}
+
+	if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok {
+		return l.funcResult(ident.Ident, argTypes)
+	}
+
+	return l.argType(tree, cmd.Args[0], dot, vars)
+}
+
+// argType checks an argument node and returns its static type.
+func (l *linter) argType(tree *parse.Tree, node parse.Node, dot reflect.Type, vars []variable) reflect.Type {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return l.fieldChain(tree, n, dot, n.Ident)
+	case *parse.VariableNode:
+		typ, found := lookupVariable(vars, n.Ident[0])
+		if !found {
+			return nil
+		}
+		return l.fieldChain(tree, n, typ, n.Ident[1:])
+	case *parse.ChainNode:
+		return l.fieldChain(tree, n, l.argType(tree, n.Node, dot, vars), n.Field)
+	case *parse.PipeNode:
+		return l.pipeType(tree, n, dot, vars)
+	case *parse.IdentifierNode:
+		return l.funcResult(n.Ident, nil)
+	case *parse.StringNode:
+		return reflect.TypeFor[string]()
+	case *parse.BoolNode:
+		return reflect.TypeFor[bool]()
+	case *parse.NumberNode:
+		if n.IsInt {
+			return reflect.TypeFor[int]()
+		}
+		return reflect.TypeFor[float64]()
+	}
+	return nil
+}
+
+// fieldChain resolves a chain of field names starting from typ.
+// Returns nil once the type becomes unknown (e.g. interface values).
+func (l *linter) fieldChain(tree *parse.Tree, node parse.Node, typ reflect.Type, idents []string) reflect.Type {
+	for _, ident := range idents {
+		if typ == nil {
+			return nil
+		}
+		next, ok := resolveField(typ, ident)
+		if !ok {
+			l.report(tree, node, "can't evaluate field %s in type %s", ident, typ)
+			return nil
+		}
+		typ = next
+	}
+	return typ
+}
+
+// funcResult returns the result type of a function call.
+func (l *linter) funcResult(name string, argTypes []reflect.Type) reflect.Type {
+	switch name {
+	case "not", "eq", "ne", "lt", "le", "gt", "ge":
+		return reflect.TypeFor[bool]()
+	case "len":
+		return reflect.TypeFor[int]()
+	case "print", "printf", "println", "html", "js", "urlquery":
+		return reflect.TypeFor[string]()
+	case "index":
+		if len(argTypes) == 0 {
+			return nil
+		}
+		typ := argTypes[0]
+		for range argTypes[1:] {
+			_, typ = rangeTypes(typ)
+		}
+		return typ
+	case "slice":
+		if len(argTypes) == 0 {
+			return nil
+		}
+		return argTypes[0]
+	}
+
+	fn, ok := l.funcs[name]
+	if !ok {
+		return nil
+	}
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func || fnType.NumOut() == 0 {
+		return nil
+	}
+	out := fnType.Out(0)
+	if out.Kind() == reflect.Interface {
+		return nil
+	}
+	return out
+}
+
+// lookupVariable finds the innermost variable with the given name.
+func lookupVariable(vars []variable, name string) (reflect.Type, bool) {
+	for i := len(vars) - 1; i >= 0; i-- {
+		if vars[i].name == name {
+			return vars[i].typ, true
+		}
+	}
+	return nil, false
+}
+
+// resolveField resolves a field or method name on a type the same way
+// text/template does at execution time. The returned type is nil when the
+// result type cannot be known statically.
+func resolveField(typ reflect.Type, name string) (reflect.Type, bool) {
+	if typ.Kind() == reflect.Interface {
+		return nil, true
+	}
+
+	if method, ok := typ.MethodByName(name); ok {
+		return methodResult(method.Type), true
+	}
+	if typ.Kind() != reflect.Pointer {
+		if method, ok := reflect.PointerTo(typ).MethodByName(name); ok {
+			return methodResult(method.Type), true
+		}
+	}
+
+	base := typ
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	switch base.Kind() {
+	case reflect.Struct:
+		if field, ok := base.FieldByName(name); ok && field.IsExported() {
+			return knownType(field.Type), true
+		}
+	case reflect.Map:
+		if base.Key().Kind() == reflect.String {
+			return knownType(base.Elem()), true
+		}
+	case reflect.Interface:
+		return nil, true
+	}
+
+	return nil, false
+}
+
+// methodResult returns the first result type of a method, or nil.
+func methodResult(fnType reflect.Type) reflect.Type {
+	if fnType.NumOut() == 0 {
+		return nil
+	}
+	return knownType(fnType.Out(0))
+}
+
+// knownType returns nil for interface types whose dynamic type is unknown.
+func knownType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Interface {
+		return nil
+	}
+	return typ
+}
+
+// rangeTypes returns the key and element types when ranging over typ.
+func rangeTypes(typ reflect.Type) (reflect.Type, reflect.Type) {
+	if typ == nil {
+		return nil, nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeFor[int](), knownType(typ.Elem())
+	case reflect.Map:
+		return knownType(typ.Key()), knownType(typ.Elem())
+	case reflect.Chan:
+		return nil, knownType(typ.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return typ, typ
+	}
+	return nil, nil
+}
+
+// typeName returns a printable name for a possibly unknown type.
+func typeName(typ reflect.Type) string {
+	if typ == nil {
+		return "?"
+	}
+	return typ.String()
+}
@@ -0,0 +1,84 @@
+package template
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func lintString(t *testing.T, content string) []LintIssue {
+	t.Helper()
+	tmpl, err := template.New("inventory").Funcs(FuncMap()).Parse(content)
+	if err != nil {
+		t.Fatalf("parsing template: %v", err)
+	}
+	return Lint(tmpl, FuncMap(), SampleRoleData())
+}
+
+func TestLint_ValidTemplate(t *testing.T) {
+	content := `# {{.RoleName}}
+{{- range $name := .SectionOrder}}
+{{- with index $.Sections $name}}
+## {{.Name}}
+{{- range .Variables}}
+{{formatTypeComment .Type}}
+{{.Name}}: {{renderMultilineValueAdjusted .Name .InstanceName .ValueLines}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if .DockerInfo}}{{range .DockerInfo.CategoryOrder}}{{.}}{{end}}{{end}}
+{{- range $suffix, $var := .RoleVarLookups}}{{$var.Suffix}}{{end}}
+{{- if .Config}}{{.Config.ProjectDescription.Name}}{{end}}`
+
+	if issues := lintString(t, content); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestLint_UnknownField(t *testing.T) {
+	content := "line one\n{{range .SectionOrder}}{{end}}\n{{range .Sectons}}{{end}}"
+
+	issues := lintString(t, content)
+	if len(issues) == 0 {
+		t.Fatal("expected issues for unknown field")
+	}
+
+	found := false
+	for _, issue := range issues {
+		if strings.Contains(issue.Message, "can't evaluate field Sectons") {
+			found = true
+			if issue.Location != "inventory:3:8" {
+				t.Errorf("expected location inventory:3:8, got %q", issue.Location)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected field error for Sectons, got %v", issues)
+	}
+}
+
+func TestLint_NestedScopes(t *testing.T) {
+	content := `{{range .SectionOrder}}{{with index $.Sections .}}{{range .Variables}}{{.Nmae}}{{end}}{{end}}{{end}}`
+
+	issues := lintString(t, content)
+	if len(issues) == 0 || !strings.Contains(issues[0].Message, "can't evaluate field Nmae in type *template.VariableData") {
+		t.Fatalf("expected VariableData field error, got %v", issues)
+	}
+}
+
+func TestLint_TemplateCalls(t *testing.T) {
+	content := `{{define "var"}}{{.Typo}}{{end}}{{range .Sections}}{{range .Variables}}{{template "var" .}}{{end}}{{end}}`
+
+	issues := lintString(t, content)
+	if len(issues) == 0 || !strings.Contains(issues[0].Message, "can't evaluate field Typo in type *template.VariableData") {
+		t.Fatalf("expected field error inside defined template, got %v", issues)
+	}
+}
+
+func TestLint_MethodsAndFuncResults(t *testing.T) {
+	content := `{{range .Sections}}{{if .HasContent}}{{len .Variables}}{{end}}{{end}}{{(typeKeyword "bool (true/false)") | printf "%s"}}`
+
+	if issues := lintString(t, content); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}